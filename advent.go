@@ -1,10 +1,54 @@
 package main
 
 import (
-	"advent/day24"
+	_ "advent/days"
 	"fmt"
+	"os"
 )
 
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+func commands() []command {
+	return []command{
+		{"run", "run --day N [--year Y] [--part P] [--input FILE] [--opt KEY=VALUE...] [--render FILE] [--export-graph FORMAT:PATH] [--verbose|--log-level LEVEL] [--log FILE] [--no-cache] [--format FORMAT] [--cpuprofile FILE] [--memprofile FILE] [--trace FILE] | run --all [--year Y] [--part P] [--parallel N] [--timeout D] [--no-cache] [--format FORMAT] [--verbose|--log-level LEVEL] [--log FILE] [--cpuprofile FILE] [--memprofile FILE] [--trace FILE]", runCommand},
+		{"list", "list", listCommand},
+		{"new", "new --day N [--year Y]", newCommand},
+		{"fetch", "fetch --day N [--year Y] [--output FILE]", fetchCommand},
+		{"submit", "submit --day N --part P [--year Y] [--input FILE] [--log FILE]", submitCommand},
+		{"bench", "bench [--day N] [--year Y] [--part P] [--runs N] [--opt KEY=VALUE...] [--save FILE] [--baseline FILE]", benchCommand},
+		{"cache", "cache clear", cacheCommand},
+		{"watch", "watch --day N [--year Y] [--part P] [--input FILE|NAME] [--opt KEY=VALUE...] [--interval D]", watchCommand},
+		{"gen", "gen --day N [--year Y] [--size small|medium|large] [--seed S] [--output FILE]", genCommand},
+		{"serve", "serve [--addr ADDR] [--max-input BYTES] [--timeout D] [--max-solves N] [--verbose|--log-level LEVEL] [--log FILE]", serveCommand},
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(os.Stderr, "  advent %s\n", cmd.usage)
+	}
+}
+
 func main() {
-	fmt.Println(day24.Run())
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands() {
+		if cmd.name != os.Args[1] {
+			continue
+		}
+		if err := cmd.run(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "advent:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	usage()
+	os.Exit(2)
 }
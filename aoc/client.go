@@ -0,0 +1,192 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	BaseURL   = "https://adventofcode.com"
+	UserAgent = "advent (github.com/incrop/advent-2023)"
+	// Interval is the minimal pause between two requests of a client.
+	Interval = 3 * time.Second
+	// SessionEnv names the variable holding the session cookie, which is
+	// otherwise read from the session file in the user config directory.
+	SessionEnv = "AOC_SESSION"
+)
+
+type Client struct {
+	BaseURL   string
+	UserAgent string
+	Session   string
+	Interval  time.Duration
+	HTTP      *http.Client
+	// LastFile keeps the time of the last request, so that separate runs of
+	// the command keep Interval between them too. Empty means the time is
+	// only kept by the client.
+	LastFile string
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func NewClient(session string) *Client {
+	return &Client{
+		BaseURL:   BaseURL,
+		UserAgent: UserAgent,
+		Session:   session,
+		Interval:  Interval,
+		HTTP:      &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// DefaultClient uses the Session and keeps the time of its last request
+// next to the session file.
+func DefaultClient() (*Client, error) {
+	session, err := Session()
+	if err != nil {
+		return nil, err
+	}
+	client := NewClient(session)
+	if client.LastFile, err = configPath("last-request"); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func configPath(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "advent", name), nil
+}
+
+// Session reads the session cookie from the environment or the config file.
+func Session() (string, error) {
+	if session := os.Getenv(SessionEnv); session != "" {
+		return session, nil
+	}
+	path, err := configPath("session")
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("no session: set %s or write it to %s", SessionEnv, path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// wait delays the request until Interval has passed since the previous one
+// got its response, by this client or by any other using the same LastFile.
+func (c *Client) wait(ctx context.Context) error {
+	last := c.last
+	if c.LastFile != "" {
+		if data, err := os.ReadFile(c.LastFile); err == nil {
+			if stamp, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data))); err == nil && stamp.After(last) {
+				last = stamp
+			}
+		}
+	}
+	if delay := time.Until(last.Add(c.Interval)); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// stamp records the end of a request. Failing to write LastFile only loses
+// the pause for the next run, so it is not an error.
+func (c *Client) stamp() {
+	c.last = time.Now()
+	if c.LastFile != "" {
+		if os.MkdirAll(filepath.Dir(c.LastFile), 0o755) == nil {
+			os.WriteFile(c.LastFile, []byte(c.last.Format(time.RFC3339Nano)+"\n"), 0o644)
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, contentType string) ([]byte, error) {
+	if c.Session == "" {
+		return nil, errors.New("no session")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	defer c.stamp()
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil, "")
+}
+
+// FetchInput downloads the input into path unless the file already has
+// content, and tells whether it did.
+func (c *Client) FetchInput(ctx context.Context, year, day int, path string) (bool, error) {
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		return false, nil
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	data, err := c.Input(ctx, year, day)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	return true, os.Rename(tmp.Name(), path)
+}
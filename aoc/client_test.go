@@ -0,0 +1,117 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newServer(t *testing.T, requests *[]time.Time) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, time.Now())
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("User-Agent") != UserAgent {
+			t.Errorf("got User-Agent %q", r.Header.Get("User-Agent"))
+		}
+		if r.URL.Path != "/2023/day/7/input" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("32T3K 765\n"))
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+	client.Interval = 20 * time.Millisecond
+	return client
+}
+
+func TestFetchInput(t *testing.T) {
+	var requests []time.Time
+	client := newServer(t, &requests)
+	path := filepath.Join(t.TempDir(), "input", "day07.txt")
+
+	fetched, err := client.FetchInput(context.Background(), 2023, 7, path)
+	if err != nil || !fetched {
+		t.Fatalf("got %v, %v", fetched, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "32T3K 765\n" {
+		t.Errorf("got input %q", data)
+	}
+	fetched, err = client.FetchInput(context.Background(), 2023, 7, path)
+	if err != nil || fetched {
+		t.Errorf("fetched again: %v, %v", fetched, err)
+	}
+	if len(requests) != 1 {
+		t.Errorf("got %d requests, want 1", len(requests))
+	}
+}
+
+func TestFetchInputErrors(t *testing.T) {
+	var requests []time.Time
+	client := newServer(t, &requests)
+	dir := t.TempDir()
+
+	if _, err := client.FetchInput(context.Background(), 2023, 8, filepath.Join(dir, "day08.txt")); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("got %v, want not found", err)
+	}
+	client.Session = "stale"
+	if _, err := client.FetchInput(context.Background(), 2023, 7, filepath.Join(dir, "day07.txt")); err == nil || !strings.Contains(err.Error(), "log in") {
+		t.Errorf("got %v, want login error", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("left files behind: %v", entries)
+	}
+	if len(requests) == 2 && requests[1].Sub(requests[0]) < client.Interval {
+		t.Errorf("requests were %v apart", requests[1].Sub(requests[0]))
+	}
+}
+
+func TestLastFile(t *testing.T) {
+	var requests []time.Time
+	first := newServer(t, &requests)
+	first.LastFile = filepath.Join(t.TempDir(), "advent", "last-request")
+	second := NewClient(first.Session)
+	second.BaseURL, second.Interval, second.LastFile = first.BaseURL, first.Interval, first.LastFile
+	dir := t.TempDir()
+
+	for i, client := range []*Client{first, second} {
+		if _, err := client.FetchInput(context.Background(), 2023, 7, filepath.Join(dir, fmt.Sprintf("day07-%d.txt", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if requests[1].Sub(requests[0]) < first.Interval {
+		t.Errorf("requests were %v apart", requests[1].Sub(requests[0]))
+	}
+}
+
+func TestSession(t *testing.T) {
+	t.Setenv(SessionEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	if _, err := Session(); err == nil {
+		t.Error("expected an error without a session")
+	}
+	dir, _ := os.UserConfigDir()
+	os.MkdirAll(filepath.Join(dir, "advent"), 0o755)
+	os.WriteFile(filepath.Join(dir, "advent", "session"), []byte("from-file\n"), 0o600)
+	if session, err := Session(); err != nil || session != "from-file" {
+		t.Errorf("got %q, %v", session, err)
+	}
+	t.Setenv(SessionEnv, "from-env")
+	if session, _ := Session(); session != "from-env" {
+		t.Errorf("got %q", session)
+	}
+}
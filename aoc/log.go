@@ -0,0 +1,89 @@
+package aoc
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+type Attempt struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  int       `json:"answer"`
+	Verdict Verdict   `json:"verdict"`
+	Time    time.Time `json:"time"`
+}
+
+// Log keeps every answer submitted so far, one JSON object per line.
+type Log struct {
+	path     string
+	attempts []Attempt
+}
+
+func OpenLog(path string) (*Log, error) {
+	log := &Log{path: path}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		var attempt Attempt
+		if err := json.Unmarshal(scanner.Bytes(), &attempt); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		log.attempts = append(log.attempts, attempt)
+	}
+	return log, scanner.Err()
+}
+
+// Check tells why the answer must not be submitted, judging by the attempts
+// made for the same puzzle before.
+func (log *Log) Check(year, day, part, answer int) error {
+	for _, attempt := range log.attempts {
+		if attempt.Year != year || attempt.Day != day || attempt.Part != part {
+			continue
+		}
+		switch {
+		case attempt.Verdict == Correct && attempt.Answer == answer:
+			return fmt.Errorf("%d is already known to be correct", answer)
+		case attempt.Verdict == Correct:
+			return fmt.Errorf("%d is wrong, the correct answer is %d", answer, attempt.Answer)
+		case attempt.Answer == answer && attempt.Verdict != Wait:
+			return fmt.Errorf("%d was already submitted: %v", answer, attempt.Verdict)
+		case attempt.Verdict == TooHigh && answer >= attempt.Answer:
+			return fmt.Errorf("%d is too high, %d already was", answer, attempt.Answer)
+		case attempt.Verdict == TooLow && answer <= attempt.Answer:
+			return fmt.Errorf("%d is too low, %d already was", answer, attempt.Answer)
+		}
+	}
+	return nil
+}
+
+func (log *Log) Record(attempt Attempt) error {
+	data, err := json.Marshal(attempt)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(log.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	log.attempts = append(log.attempts, attempt)
+	return nil
+}
@@ -0,0 +1,133 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Verdict int
+
+const (
+	Unknown Verdict = iota
+	Correct
+	TooHigh
+	TooLow
+	Wrong
+	Wait
+	Solved
+)
+
+func (verdict Verdict) String() string {
+	switch verdict {
+	case Correct:
+		return "correct"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case Wrong:
+		return "wrong"
+	case Wait:
+		return "wait"
+	case Solved:
+		return "already solved"
+	default:
+		return "unknown"
+	}
+}
+
+func (verdict Verdict) MarshalText() ([]byte, error) {
+	return []byte(verdict.String()), nil
+}
+
+func (verdict *Verdict) UnmarshalText(text []byte) error {
+	for v := Unknown; v <= Solved; v++ {
+		if v.String() == string(text) {
+			*verdict = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+type Outcome struct {
+	Verdict Verdict
+	// Wait is how long to wait before the next attempt, if the response says.
+	Wait    time.Duration
+	Message string
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+	waitRe    = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait|wait (\w+) minutes?`)
+)
+
+var minutes = map[string]time.Duration{"one": 1, "two": 2, "three": 3, "five": 5, "ten": 10}
+
+func parseWait(message string) time.Duration {
+	match := waitRe.FindStringSubmatch(message)
+	switch {
+	case match == nil:
+		return 0
+	case match[3] != "":
+		if n, err := strconv.Atoi(match[3]); err == nil {
+			return time.Duration(n) * time.Minute
+		}
+		return minutes[match[3]] * time.Minute
+	default:
+		m, _ := strconv.Atoi(match[1])
+		s, _ := strconv.Atoi(match[2])
+		return time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	}
+}
+
+// ParseOutcome reads the verdict from the HTML page returned on submission.
+func ParseOutcome(page string) Outcome {
+	message := page
+	if match := articleRe.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = strings.TrimSpace(spaceRe.ReplaceAllString(tagRe.ReplaceAllString(message, " "), " "))
+	outcome := Outcome{Message: message, Wait: parseWait(message)}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		outcome.Verdict = Correct
+	case strings.Contains(message, "You gave an answer too recently"):
+		outcome.Verdict = Wait
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		outcome.Verdict = Solved
+	case strings.Contains(message, "That's not the right answer"):
+		switch {
+		case strings.Contains(message, "your answer is too high"):
+			outcome.Verdict = TooHigh
+		case strings.Contains(message, "your answer is too low"):
+			outcome.Verdict = TooLow
+		default:
+			outcome.Verdict = Wrong
+		}
+	}
+	return outcome
+}
+
+func (c *Client) Submit(ctx context.Context, year, day, part, answer int) (Outcome, error) {
+	form := url.Values{
+		"level":  {strconv.Itoa(part)},
+		"answer": {strconv.Itoa(answer)},
+	}
+	page, err := c.do(ctx, http.MethodPost,
+		fmt.Sprintf("/%d/day/%d/answer", year, day),
+		strings.NewReader(form.Encode()),
+		"application/x-www-form-urlencoded")
+	if err != nil {
+		return Outcome{}, err
+	}
+	return ParseOutcome(string(page)), nil
+}
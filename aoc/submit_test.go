@@ -0,0 +1,109 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const page = `<!DOCTYPE html><html><body><main>
+<article><p>%s</p></article>
+</main></body></html>`
+
+func TestParseOutcome(t *testing.T) {
+	for _, c := range []struct {
+		message string
+		verdict Verdict
+		wait    time.Duration
+	}{
+		{`That's the right answer!  You are <span class="day-success">one gold star</span> closer to restoring snow operations. <a href="/2023/day/7#part2">[Continue to Part Two]</a>`, Correct, 0},
+		{`That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. <a href="/2023/day/7">[Return to Day 7]</a>`, TooHigh, time.Minute},
+		{`That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.`, TooLow, 5 * time.Minute},
+		{`That's not the right answer.  If you're stuck, make sure you're using the full input data.`, Wrong, 0},
+		{`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 23s left to wait.`, Wait, 83 * time.Second},
+		{`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 45s left to wait.`, Wait, 45 * time.Second},
+		{`You don't seem to be solving the right level.  Did you already complete it?`, Solved, 0},
+		{`Something else entirely.`, Unknown, 0},
+	} {
+		outcome := ParseOutcome(fmt.Sprintf(page, c.message))
+		if outcome.Verdict != c.verdict || outcome.Wait != c.wait {
+			t.Errorf("%q: got %v and %v, want %v and %v", outcome.Message, outcome.Verdict, outcome.Wait, c.verdict, c.wait)
+		}
+	}
+}
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attempts.jsonl")
+	log, err := OpenLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, attempt := range []Attempt{
+		{Year: 2023, Day: 7, Part: 1, Answer: 500, Verdict: TooHigh},
+		{Year: 2023, Day: 7, Part: 1, Answer: 100, Verdict: TooLow},
+		{Year: 2023, Day: 7, Part: 1, Answer: 300, Verdict: Wrong},
+		{Year: 2023, Day: 7, Part: 2, Answer: 42, Verdict: Correct},
+	} {
+		if err := log.Record(attempt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if log, err = OpenLog(path); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		part, answer int
+		allowed      bool
+	}{
+		{1, 200, true},
+		{1, 300, false},
+		{1, 500, false},
+		{1, 600, false},
+		{1, 100, false},
+		{1, 50, false},
+		{2, 42, false},
+		{2, 43, false},
+	} {
+		if err := log.Check(2023, 7, c.part, c.answer); (err == nil) != c.allowed {
+			t.Errorf("part %d answer %d: got %v", c.part, c.answer, err)
+		}
+	}
+	if err := log.Check(2023, 8, 1, 300); err != nil {
+		t.Errorf("other day: got %v", err)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/7/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "2" {
+			t.Errorf("got level %q", r.FormValue("level"))
+		}
+		message := "That's not the right answer; your answer is too low."
+		if r.FormValue("answer") == "5905" {
+			message = "That's the right answer!"
+		}
+		fmt.Fprintf(w, page, message)
+	}))
+	defer server.Close()
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+	client.Interval = 0
+
+	for answer, want := range map[int]Verdict{5905: Correct, 5904: TooLow} {
+		outcome, err := client.Submit(context.Background(), 2023, 7, 2, answer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outcome.Verdict != want {
+			t.Errorf("answer %d: got %v, want %v", answer, outcome.Verdict, want)
+		}
+	}
+}
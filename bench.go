@@ -0,0 +1,60 @@
+package main
+
+import (
+	"advent/bench"
+	"advent/registry"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	dayNum := flags.Int("day", 0, "day to benchmark (default: all)")
+	year := flags.Int("year", registry.DefaultYear, "puzzle year")
+	part := flags.Int("part", 0, "part to benchmark (default: both)")
+	runs := flags.Int("runs", 1, "how many times to repeat each measurement")
+	save := flags.String("save", "", "write results as a JSON baseline to this file")
+	baseline := flags.String("baseline", "", "compare results with a JSON baseline from this file")
+	opts := make(registry.Options)
+	flags.Var(opts, "opt", "puzzle option as key=value, can be repeated (see advent list)")
+	flags.Parse(args)
+
+	parts, err := selectParts(*part)
+	if err != nil {
+		return err
+	}
+	if *runs < 1 {
+		return fmt.Errorf("cannot do %d runs", *runs)
+	}
+	days := registry.Year(*year)
+	if *dayNum != 0 {
+		day, found := registry.Get(*year, *dayNum)
+		if !found {
+			return fmt.Errorf("%d day %d is not registered", *year, *dayNum)
+		}
+		days = []registry.Day{day}
+	}
+
+	var previous []bench.Result
+	if *baseline != "" {
+		if previous, err = bench.Load(*baseline); err != nil {
+			return err
+		}
+	}
+	results := make([]bench.Result, 0, len(days))
+	for _, day := range days {
+		result, err := bench.Run(day, day.DefaultInput(), day.Known(opts), parts, *runs)
+		if err != nil {
+			return fmt.Errorf("%v: %w", day, err)
+		}
+		results = append(results, result)
+	}
+	if err := bench.Report(os.Stdout, results, previous); err != nil {
+		return err
+	}
+	if *save != "" {
+		return bench.Save(*save, results)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package bench
+
+import (
+	"advent/registry"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+// Stats are averaged over runs, except Peak which is the largest heap
+// growth seen during any run.
+type Stats struct {
+	Time   time.Duration `json:"time"`
+	Allocs uint64        `json:"allocs"`
+	Bytes  uint64        `json:"bytes"`
+	Peak   uint64        `json:"peak"`
+}
+
+type Result struct {
+	Year  int           `json:"year"`
+	Day   int           `json:"day"`
+	Input string        `json:"input"`
+	Parse Stats         `json:"parse"`
+	Parts map[int]Stats `json:"parts"`
+}
+
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// heapBytes reuses the sample, so that sampling does not allocate while
+// the allocations of f are counted.
+func heapBytes(sample []metrics.Sample) uint64 {
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
+
+// measure runs f the given number of times, sampling the live heap in the
+// background to estimate peak memory.
+func measure(runs int, f func() error) (stats Stats, err error) {
+	sample := []metrics.Sample{{Name: heapMetric}}
+	for n := 0; n < runs; n++ {
+		runtime.GC()
+		base := heapBytes(sample)
+		var peak uint64
+		done := make(chan struct{})
+		ticker := time.NewTicker(time.Millisecond)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer ticker.Stop()
+			for {
+				if heap := heapBytes(sample); heap > base && heap-base > peak {
+					peak = heap - base
+				}
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		err = f()
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		close(done)
+		wg.Wait()
+		if err != nil {
+			return
+		}
+		stats.Time += elapsed
+		stats.Allocs += after.Mallocs - before.Mallocs
+		stats.Bytes += after.TotalAlloc - before.TotalAlloc
+		stats.Peak = max(stats.Peak, peak)
+	}
+	stats.Time /= time.Duration(runs)
+	stats.Allocs /= uint64(runs)
+	stats.Bytes /= uint64(runs)
+	return
+}
+
+// Run benchmarks parsing the input and solving each of the parts. The input
+// is read into memory first so that parse times do not include disk access.
+func Run(day registry.Day, input string, opts registry.Options, parts []int, runs int) (result Result, err error) {
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return
+	}
+	result = Result{Year: day.Year, Day: day.Number, Input: input, Parts: make(map[int]Stats)}
+	var solver registry.Solver
+	result.Parse, err = measure(runs, func() (err error) {
+		solver, err = day.Parse(bytes.NewReader(data), opts)
+		return
+	})
+	if err != nil {
+		return
+	}
+	for _, part := range parts {
+		var stats Stats
+		stats, err = measure(runs, func() error {
+			_, err := day.Solve(context.Background(), solver, part)
+			return err
+		})
+		if errors.Is(err, registry.ErrNoPuzzle) {
+			err = nil
+			continue
+		}
+		if err != nil {
+			return
+		}
+		result.Parts[part] = stats
+	}
+	return
+}
+
+func Save(path string, results []Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func Load(path string) ([]Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var results []Result
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
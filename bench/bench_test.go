@@ -0,0 +1,66 @@
+package bench
+
+import (
+	"advent/registry"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type last struct{}
+
+func (last) Part1(ctx context.Context) (int, error) { return 1, nil }
+func (last) Part2(ctx context.Context) (int, error) { return 0, registry.ErrNoPuzzle }
+
+func TestRun(t *testing.T) {
+	registry.Register(2000, 301, func(r io.Reader) (registry.Solver, error) {
+		return last{}, nil
+	})
+	day, _ := registry.Get(2000, 301)
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(input, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Run(day, input, nil, []int{1, 2}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := result.Parts[1]; !found || len(result.Parts) != 1 || result.Year != 2000 || result.Day != 301 {
+		t.Errorf("got %+v", result)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	results, err := Load("testdata/baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Parse.Allocs != 100 || results[0].Parts[1].Peak != 1024 {
+		t.Errorf("got %+v", results)
+	}
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := Save(path, results); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, results) {
+		t.Errorf("got %+v, want %+v", loaded, results)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture, err := os.ReadFile("testdata/baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(saved) != string(fixture) {
+		t.Errorf("saved\n%s\nwant\n%s", saved, fixture)
+	}
+}
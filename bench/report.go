@@ -0,0 +1,80 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value, suffix := float64(n), "KMGT"
+	for i := range suffix {
+		value /= unit
+		if value < unit || i == len(suffix)-1 {
+			return fmt.Sprintf("%.1f%ciB", value, suffix[i])
+		}
+	}
+	panic("unreachable")
+}
+
+func formatChange(now, then float64) string {
+	if then == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%+.1f%%)", (now-then)/then*100)
+}
+
+func writeStats(w io.Writer, label string, stats Stats, old *Stats) {
+	var change [3]string
+	if old != nil {
+		change[0] = formatChange(float64(stats.Time), float64(old.Time))
+		change[1] = formatChange(float64(stats.Allocs), float64(old.Allocs))
+		change[2] = formatChange(float64(stats.Peak), float64(old.Peak))
+	}
+	fmt.Fprintf(w, "%s\t%v%s\t%d%s\t%s\t%s%s\n",
+		label,
+		stats.Time.Round(time.Microsecond), change[0],
+		stats.Allocs, change[1],
+		formatBytes(stats.Bytes),
+		formatBytes(stats.Peak), change[2])
+}
+
+// Report prints a table of results, with changes relative to the baseline
+// for every day and part that it also contains.
+func Report(w io.Writer, results, baseline []Result) error {
+	type key struct{ year, day int }
+	previous := make(map[key]Result)
+	for _, result := range baseline {
+		previous[key{result.Year, result.Day}] = result
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\ttime\tallocs\tbytes\tpeak")
+	for _, result := range results {
+		old, found := previous[key{result.Year, result.Day}]
+		var oldStats *Stats
+		if found {
+			oldStats = &old.Parse
+		}
+		writeStats(tw, fmt.Sprintf("%d day %02d parse", result.Year, result.Day), result.Parse, oldStats)
+
+		parts := make([]int, 0, len(result.Parts))
+		for part := range result.Parts {
+			parts = append(parts, part)
+		}
+		sort.Ints(parts)
+		for _, part := range parts {
+			oldStats = nil
+			if stats, found := old.Parts[part]; found {
+				oldStats = &stats
+			}
+			writeStats(tw, fmt.Sprintf("%d day %02d part %d", result.Year, result.Day, part), result.Parts[part], oldStats)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,55 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[uint64]string{
+		0:       "0B",
+		1023:    "1023B",
+		1024:    "1.0KiB",
+		1536:    "1.5KiB",
+		1 << 20: "1.0MiB",
+		5 << 30: "5.0GiB",
+		1 << 50: "1024.0TiB",
+	} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("%d: got %s, want %s", n, got, want)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	baseline, err := Load("testdata/baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []Result{
+		{
+			Year:  2000,
+			Day:   1,
+			Parse: Stats{Time: time.Millisecond, Allocs: 150, Bytes: 2048, Peak: 4096},
+			Parts: map[int]Stats{
+				2: {Time: 3 * time.Millisecond, Allocs: 10, Bytes: 100, Peak: 100},
+				1: {Time: 15 * time.Millisecond, Allocs: 25, Bytes: 1536, Peak: 2048},
+			},
+		},
+		{Year: 2000, Day: 2, Parse: Stats{Time: time.Microsecond}},
+	}
+	var out strings.Builder
+	if err := Report(&out, results, baseline); err != nil {
+		t.Fatal(err)
+	}
+	want := `                    time           allocs        bytes   peak
+2000 day 01 parse   1ms (-50.0%)   150 (+50.0%)  2.0KiB  4.0KiB (+0.0%)
+2000 day 01 part 1  15ms (+50.0%)  25 (-50.0%)   1.5KiB  2.0KiB (+100.0%)
+2000 day 01 part 2  3ms            10            100B    100B
+2000 day 02 parse   1µs            0             0B      0B
+`
+	if got := out.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
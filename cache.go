@@ -0,0 +1,26 @@
+package main
+
+import (
+	"advent/cache"
+	"errors"
+	"flag"
+	"fmt"
+)
+
+func cacheCommand(args []string) error {
+	flags := flag.NewFlagSet("cache", flag.ExitOnError)
+	flags.Parse(args)
+	if flags.NArg() != 1 || flags.Arg(0) != "clear" {
+		return errors.New("expected cache clear")
+	}
+	answers, err := cache.Open()
+	if err != nil {
+		return err
+	}
+	count, err := answers.Clear()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("cleared %d answers from %s\n", count, answers.Dir)
+	return nil
+}
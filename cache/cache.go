@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Cache keeps answers in files named after the hash of everything that
+// went into them, so stale answers are never looked up again.
+type Cache struct {
+	Dir string
+}
+
+// Open uses advent/answers under the user cache directory.
+func Open() (*Cache, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{filepath.Join(dir, "advent", "answers")}, nil
+}
+
+// Key hashes the input along with the year, day, part, options and the
+// version of the solver. Options are expected in their canonical form.
+func Key(input []byte, year, day, part int, opts string, version string) string {
+	hash := sha256.New()
+	hash.Write(input)
+	fmt.Fprintf(hash, "\x00year=%d\x00day=%d\x00part=%d\x00opts=%s\x00version=%s", year, day, part, opts, version)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (cache *Cache) path(key string) string {
+	return filepath.Join(cache.Dir, key[:2], key)
+}
+
+func (cache *Cache) Get(key string) (int, bool) {
+	data, err := os.ReadFile(cache.path(key))
+	if err != nil {
+		return 0, false
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	return value, err == nil
+}
+
+func (cache *Cache) Put(key string, value int) error {
+	path := cache.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".answer-*")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(tmp, value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Clear removes every cached answer, and reports how many there were.
+func (cache *Cache) Clear() (count int, err error) {
+	err = filepath.WalkDir(cache.Dir, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			count++
+		}
+		return err
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, os.RemoveAll(cache.Dir)
+}
@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	cache := &Cache{t.TempDir()}
+	key := Key([]byte("1abc2\n"), 2023, 1, 1, "", "v1")
+	if _, found := cache.Get(key); found {
+		t.Fatal("empty cache has an answer")
+	}
+	if err := cache.Put(key, 12); err != nil {
+		t.Fatal(err)
+	}
+	if value, found := cache.Get(key); !found || value != 12 {
+		t.Errorf("got %d, %v", value, found)
+	}
+	count, err := cache.Clear()
+	if err != nil || count != 1 {
+		t.Errorf("cleared %d, %v", count, err)
+	}
+	if _, found := cache.Get(key); found {
+		t.Error("answer survived clearing")
+	}
+	if count, err := cache.Clear(); err != nil || count != 0 {
+		t.Errorf("cleared %d, %v again", count, err)
+	}
+}
+
+func TestKey(t *testing.T) {
+	base := Key([]byte("input"), 2023, 5, 1, "seed-ranges=true", "v1")
+	for _, other := range []string{
+		Key([]byte("input\n"), 2023, 5, 1, "seed-ranges=true", "v1"),
+		Key([]byte("input"), 2022, 5, 1, "seed-ranges=true", "v1"),
+		Key([]byte("input"), 2023, 6, 1, "seed-ranges=true", "v1"),
+		Key([]byte("input"), 2023, 5, 2, "seed-ranges=true", "v1"),
+		Key([]byte("input"), 2023, 5, 1, "", "v1"),
+		Key([]byte("input"), 2023, 5, 1, "seed-ranges=true", "v2"),
+	} {
+		if other == base {
+			t.Errorf("key %s does not change", base)
+		}
+	}
+}
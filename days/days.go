@@ -0,0 +1,29 @@
+package days
+
+import (
+	_ "advent/y2023/day01"
+	_ "advent/y2023/day02"
+	_ "advent/y2023/day03"
+	_ "advent/y2023/day04"
+	_ "advent/y2023/day05"
+	_ "advent/y2023/day06"
+	_ "advent/y2023/day07"
+	_ "advent/y2023/day08"
+	_ "advent/y2023/day09"
+	_ "advent/y2023/day10"
+	_ "advent/y2023/day11"
+	_ "advent/y2023/day12"
+	_ "advent/y2023/day13"
+	_ "advent/y2023/day14"
+	_ "advent/y2023/day15"
+	_ "advent/y2023/day16"
+	_ "advent/y2023/day17"
+	_ "advent/y2023/day18"
+	_ "advent/y2023/day19"
+	_ "advent/y2023/day20"
+	_ "advent/y2023/day21"
+	_ "advent/y2023/day22"
+	_ "advent/y2023/day23"
+	_ "advent/y2023/day24"
+	_ "advent/y2023/day25"
+)
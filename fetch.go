@@ -0,0 +1,38 @@
+package main
+
+import (
+	"advent/aoc"
+	"advent/registry"
+	"context"
+	"flag"
+	"fmt"
+)
+
+func fetchCommand(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	dayNum := flags.Int("day", 0, "day to fetch the input for")
+	year := flags.Int("year", registry.DefaultYear, "puzzle year")
+	output := flags.String("output", "", "where to save the input (default: input/YEAR/dayNN.txt)")
+	flags.Parse(args)
+
+	if *dayNum < 1 || *dayNum > 25 {
+		return fmt.Errorf("there is no day %d", *dayNum)
+	}
+	if *output == "" {
+		*output = registry.InputPath(*year, *dayNum)
+	}
+	client, err := aoc.DefaultClient()
+	if err != nil {
+		return err
+	}
+	fetched, err := client.FetchInput(context.Background(), *year, *dayNum, *output)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		fmt.Println("fetched", *output)
+	} else {
+		fmt.Println(*output, "already exists")
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"advent/gen"
+	"advent/registry"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+)
+
+func genCommand(args []string) error {
+	flags := flag.NewFlagSet("gen", flag.ExitOnError)
+	dayNum := flags.Int("day", 0, "day to generate an input for")
+	year := flags.Int("year", registry.DefaultYear, "puzzle year")
+	sizeName := flags.String("size", "small", "how big the input is: small, medium or large, which is about real size")
+	seed := flags.Uint64("seed", 0, "generate the same input again for the same seed (default: a random seed)")
+	output := flags.String("output", "", "where to write the input (default: stdout)")
+	flags.Parse(args)
+
+	generate, found := gen.Get(*year, *dayNum)
+	if !found {
+		return fmt.Errorf("%d day %d has no generator", *year, *dayNum)
+	}
+	size, err := gen.ParseSize(*sizeName)
+	if err != nil {
+		return err
+	}
+	if *seed == 0 {
+		*seed = rand.Uint64()
+		fmt.Fprintln(os.Stderr, "seed", *seed)
+	}
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+	return gen.Write(w, generate, *seed, size)
+}
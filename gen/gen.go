@@ -0,0 +1,99 @@
+package gen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand/v2"
+)
+
+// Size scales the generated inputs, Large being about as big as the real
+// ones.
+type Size int
+
+const (
+	Small Size = iota
+	Medium
+	Large
+)
+
+var sizeNames = []string{"small", "medium", "large"}
+
+func ParseSize(name string) (Size, error) {
+	for i, sizeName := range sizeNames {
+		if name == sizeName {
+			return Size(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown size %q, expected small, medium or large", name)
+}
+
+func (size Size) String() string {
+	return sizeNames[size]
+}
+
+// Pick returns the value for the size.
+func (size Size) Pick(small, medium, large int) int {
+	return [...]int{small, medium, large}[size]
+}
+
+// Generator writes a random input that the parser of the day accepts and
+// that its solver can answer. Write errors are reported by the caller.
+type Generator func(w *bufio.Writer, rng *rand.Rand, size Size)
+
+type key struct {
+	year, number int
+}
+
+var generators = make(map[key]Generator)
+
+func Register(year, number int, generate Generator) {
+	k := key{year, number}
+	if _, exists := generators[k]; exists {
+		panic(fmt.Sprintf("%d day %d has two generators", year, number))
+	}
+	generators[k] = generate
+}
+
+func Get(year, number int) (Generator, bool) {
+	generate, found := generators[key{year, number}]
+	return generate, found
+}
+
+// Rand is seeded so that the same seed always generates the same input.
+func Rand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// Write generates the input into w.
+func Write(w io.Writer, generate Generator, seed uint64, size Size) error {
+	buffered := bufio.NewWriter(w)
+	generate(buffered, Rand(seed), size)
+	return buffered.Flush()
+}
+
+// Between returns a number from lo to hi, both included.
+func Between(rng *rand.Rand, lo, hi int) int {
+	return lo + rng.IntN(hi-lo+1)
+}
+
+// Names returns n distinct names of lowercase letters of the given length,
+// none of them in taken.
+func Names(rng *rand.Rand, n, length int, taken ...string) []string {
+	seen := make(map[string]bool, n+len(taken))
+	for _, name := range taken {
+		seen[name] = true
+	}
+	names := make([]string, 0, n)
+	letters := make([]byte, length)
+	for len(names) < n {
+		for i := range letters {
+			letters[i] = byte('a' + rng.IntN(26))
+		}
+		if name := string(letters); !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
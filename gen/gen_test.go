@@ -0,0 +1,47 @@
+package gen
+
+import (
+	"advent/utils/geom"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	for _, size := range []Size{Small, Medium, Large} {
+		if parsed, err := ParseSize(size.String()); err != nil || parsed != size {
+			t.Errorf("%v: got %v, %v", size, parsed, err)
+		}
+	}
+	if _, err := ParseSize("huge"); err == nil {
+		t.Error("parsed huge")
+	}
+}
+
+func TestNames(t *testing.T) {
+	names := Names(Rand(1), 20, 2, "rx")
+	seen := map[string]bool{"rx": true}
+	for _, name := range names {
+		if len(name) != 2 || seen[name] {
+			t.Errorf("bad or repeated name %q", name)
+		}
+		seen[name] = true
+	}
+	if len(names) != 20 {
+		t.Errorf("got %d names, want 20", len(names))
+	}
+}
+
+func TestLoop(t *testing.T) {
+	for seed := uint64(1); seed <= 20; seed++ {
+		loop := Loop(Rand(seed), 12, 9)
+		visited := make(map[geom.Point]bool)
+		for i, cell := range loop {
+			if visited[cell] || cell.I < 0 || cell.I >= 12 || cell.J < 0 || cell.J >= 9 {
+				t.Fatalf("seed %d: bad cell %v", seed, cell)
+			}
+			visited[cell] = true
+			if next := loop[(i+1)%len(loop)]; cell.Manhattan(next) != 1 {
+				t.Fatalf("seed %d: %v is not next to %v", seed, cell, next)
+			}
+		}
+	}
+}
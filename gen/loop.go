@@ -0,0 +1,54 @@
+package gen
+
+import (
+	"advent/utils/geom"
+	"math/rand/v2"
+)
+
+// Loop returns the cells of a random closed path that never crosses itself
+// inside a height by width grid, in the order they are visited. It starts
+// as a rectangle around the middle half of the grid and keeps pushing a
+// random step of the path sideways into free cells, inwards or outwards.
+func Loop(rng *rand.Rand, height, width int) []geom.Point {
+	top, left := height/4, width/4
+	bottom, right := max(top+1, height-1-height/4), max(left+1, width-1-width/4)
+	var cells []geom.Point
+	for j := left; j < right; j++ {
+		cells = append(cells, geom.Point{I: top, J: j})
+	}
+	for i := top; i < bottom; i++ {
+		cells = append(cells, geom.Point{I: i, J: right})
+	}
+	for j := right; j > left; j-- {
+		cells = append(cells, geom.Point{I: bottom, J: j})
+	}
+	for i := bottom; i > top; i-- {
+		cells = append(cells, geom.Point{I: i, J: left})
+	}
+	next := make(map[geom.Point]geom.Point, len(cells))
+	for k, cell := range cells {
+		next[cell] = cells[(k+1)%len(cells)]
+	}
+	free := func(p geom.Point) bool {
+		_, taken := next[p]
+		return !taken && p.I >= 0 && p.I < height && p.J >= 0 && p.J < width
+	}
+	for range 8 * height * width {
+		a := cells[rng.IntN(len(cells))]
+		b := next[a]
+		delta := geom.Point{I: b.J - a.J, J: b.I - a.I}
+		if rng.IntN(2) == 0 {
+			delta = geom.Point{I: -delta.I, J: -delta.J}
+		}
+		a2, b2 := a.Add(delta), b.Add(delta)
+		if free(a2) && free(b2) {
+			next[a], next[a2], next[b2] = a2, b2, b
+			cells = append(cells, a2, b2)
+		}
+	}
+	loop := make([]geom.Point, 0, len(next))
+	for p := cells[0]; len(loop) == 0 || p != cells[0]; p = next[p] {
+		loop = append(loop, p)
+	}
+	return loop
+}
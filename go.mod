@@ -1,3 +1,3 @@
 module advent
 
-go 1.21.4
+go 1.23
@@ -0,0 +1,293 @@
+package golden
+
+import (
+	"advent/gen"
+	"advent/registry"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Case describes expected answers for one input file under input/YEAR/.
+// A zero answer is not checked. A case with Err expects parsing or one of
+// the parts to fail with an error containing it instead.
+type Case struct {
+	Input   string
+	Options registry.Options
+	Part1   int
+	Part2   int
+	Err     string
+}
+
+func (c Case) name() string {
+	if len(c.Options) == 0 {
+		return c.Input
+	}
+	return c.Input + "?" + c.Options.String()
+}
+
+// Answers are read from input/YEAR/answers.json, which is not committed:
+//
+//	{"day07": {"part1": 250000000, "part2": 250000000}}
+const AnswersFile = "answers.json"
+
+type answer struct {
+	Part1 int `json:"part1"`
+	Part2 int `json:"part2"`
+}
+
+func inputDir(year int) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return filepath.Join(dir, "input", fmt.Sprint(year)), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+func realCase(dir string, number int) (c Case, found bool, err error) {
+	data, err := os.ReadFile(filepath.Join(dir, AnswersFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, false, nil
+	}
+	if err != nil {
+		return
+	}
+	answers := make(map[string]answer)
+	if err = json.Unmarshal(data, &answers); err != nil {
+		return c, false, fmt.Errorf("%s: %w", AnswersFile, err)
+	}
+	key := fmt.Sprintf("day%02d", number)
+	real, found := answers[key]
+	return Case{Input: key + ".txt", Part1: real.Part1, Part2: real.Part2}, found, nil
+}
+
+// Test checks every case against the registered solver of the day,
+// plus the real input if its answers are known locally.
+func Test(t *testing.T, year, number int, cases ...Case) {
+	t.Helper()
+	day, found := registry.Get(year, number)
+	if !found {
+		t.Fatalf("%d day %d is not registered", year, number)
+	}
+	dir, err := inputDir(year)
+	if err != nil {
+		t.Fatal(err)
+	}
+	real, found, err := realCase(dir, number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found {
+		cases = append(cases, real)
+	}
+	for _, c := range cases {
+		t.Run(c.name(), func(t *testing.T) {
+			if c.Err != "" {
+				checkErr(t, day, filepath.Join(dir, c.Input), c)
+				return
+			}
+			solver, err := day.ParseFile(filepath.Join(dir, c.Input), c.Options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for part, want := range []int{c.Part1, c.Part2} {
+				if want == 0 {
+					continue
+				}
+				t.Run(fmt.Sprintf("part%d", part+1), func(t *testing.T) {
+					got, err := day.Solve(context.Background(), solver, part+1)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if got != want {
+						t.Errorf("got %d, want %d", got, want)
+					}
+				})
+			}
+		})
+	}
+}
+
+func checkErr(t *testing.T, day registry.Day, path string, c Case) {
+	t.Helper()
+	solver, err := day.ParseFile(path, c.Options)
+	for part := 1; err == nil && part <= 2; part++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err = day.Solve(ctx, solver, part)
+		cancel()
+	}
+	if err == nil || !strings.Contains(err.Error(), c.Err) {
+		t.Errorf("got %v, want an error with %q", err, c.Err)
+	}
+}
+
+// Benchmark measures parsing and both parts on the real input of the day,
+// which is skipped when the input is missing.
+func Benchmark(b *testing.B, year, number int) {
+	b.Helper()
+	day, found := registry.Get(year, number)
+	if !found {
+		b.Fatalf("%d day %d is not registered", year, number)
+	}
+	dir, err := inputDir(year)
+	if err != nil {
+		b.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("day%02d.txt", number)))
+	if errors.Is(err, os.ErrNotExist) {
+		b.Skip("no real input")
+	}
+	if err != nil {
+		b.Fatal(err)
+	}
+	solver, err := day.Parse(bytes.NewReader(data), nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := day.Parse(bytes.NewReader(data), nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	for _, part := range []int{1, 2} {
+		part := part
+		b.Run(fmt.Sprintf("part%d", part), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := day.Solve(context.Background(), solver, part)
+				if errors.Is(err, registry.ErrNoPuzzle) {
+					b.Skip(err)
+				}
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// testInputs reads the test inputs of the day, such as input/2023/day08_test.txt.
+func testInputs(f *testing.F, year, number int) (inputs [][]byte) {
+	dir, err := inputDir(year)
+	if err != nil {
+		f.Fatal(err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("day%02d_test*.txt", number)))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		inputs = append(inputs, data)
+	}
+	return
+}
+
+// Fuzz checks that the parser of the day reports malformed input as an
+// error instead of panicking, starting from the test inputs of the day.
+func Fuzz(f *testing.F, year, number int) {
+	f.Helper()
+	day, found := registry.Get(year, number)
+	if !found {
+		f.Fatalf("%d day %d is not registered", year, number)
+	}
+	for _, data := range testInputs(f, year, number) {
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		solver, err := day.Parse(bytes.NewReader(data), nil)
+		if err == nil && solver == nil {
+			t.Error("no solver and no error")
+		}
+	})
+}
+
+// FuzzLines checks a line parser the same way, starting from every line of
+// the test inputs of the day.
+func FuzzLines[T any](f *testing.F, year, number int, parse func(string) (T, error)) {
+	f.Helper()
+	for _, data := range testInputs(f, year, number) {
+		for _, line := range strings.Split(string(data), "\n") {
+			f.Add(line)
+		}
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		parse(line)
+	})
+}
+
+// generatedCases are the seeds and sizes Generated tries. Most are small, a
+// few are larger to catch answers that only go wrong at about real size.
+var generatedCases = []struct {
+	seed uint64
+	size gen.Size
+}{
+	{1, gen.Small}, {2, gen.Small}, {3, gen.Small}, {4, gen.Small}, {5, gen.Small},
+	{6, gen.Medium}, {7, gen.Medium},
+	{8, gen.Large},
+}
+
+// Generated checks that inputs from the generator of the day are the same
+// for the same seed and that the solver answers both parts of them. Only
+// small inputs are tried with -short.
+func Generated(t *testing.T, year, number int) {
+	t.Helper()
+	day, found := registry.Get(year, number)
+	if !found {
+		t.Fatalf("%d day %d is not registered", year, number)
+	}
+	generate, found := gen.Get(year, number)
+	if !found {
+		t.Fatalf("%d day %d has no generator", year, number)
+	}
+	for _, c := range generatedCases {
+		t.Run(fmt.Sprintf("%s/seed%d", c.size, c.seed), func(t *testing.T) {
+			if testing.Short() && c.size != gen.Small {
+				t.Skip("skipping larger input in short mode")
+			}
+			var first, second bytes.Buffer
+			if err := gen.Write(&first, generate, c.seed, c.size); err != nil {
+				t.Fatal(err)
+			}
+			if err := gen.Write(&second, generate, c.seed, c.size); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(first.Bytes(), second.Bytes()) {
+				t.Fatal("the same seed generated different inputs")
+			}
+			solver, err := day.Parse(bytes.NewReader(first.Bytes()), nil)
+			if err != nil {
+				t.Fatalf("%v\n%s", err, first.Bytes())
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			for _, part := range []int{1, 2} {
+				if _, err := day.Solve(ctx, solver, part); err != nil && !errors.Is(err, registry.ErrNoPuzzle) {
+					t.Errorf("part %d: %v\n%s", part, err, first.Bytes())
+				}
+			}
+		})
+	}
+}
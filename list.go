@@ -0,0 +1,20 @@
+package main
+
+import (
+	"advent/registry"
+	"fmt"
+)
+
+func listCommand(args []string) error {
+	for _, day := range registry.All() {
+		fmt.Printf("%v  %s\n", day, day.DefaultInput())
+		for _, option := range day.Options {
+			fmt.Printf("  --opt %s", option.Name)
+			if option.Default != "" {
+				fmt.Printf("=%s", option.Default)
+			}
+			fmt.Printf("  %s\n", option.Usage)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"advent/registry"
+	"advent/scaffold"
+	"flag"
+	"fmt"
+)
+
+func newCommand(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	dayNum := flags.Int("day", 0, "day to create")
+	year := flags.Int("year", registry.DefaultYear, "puzzle year")
+	flags.Parse(args)
+
+	written, err := scaffold.New(".", *year, *dayNum)
+	for _, path := range written {
+		fmt.Println("wrote", path)
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// profiles are written while the solvers run. The runner labels samples
+// with the day and phase, so pprof -tagfocus=phase=part2 shows one part.
+type profiles struct {
+	cpu, mem, trace string
+}
+
+func (p *profiles) addFlags(flags *flag.FlagSet) {
+	flags.StringVar(&p.cpu, "cpuprofile", "", "write a CPU profile to FILE")
+	flags.StringVar(&p.mem, "memprofile", "", "write an allocation profile to FILE")
+	flags.StringVar(&p.trace, "trace", "", "write an execution trace to FILE")
+}
+
+// start begins the CPU profile and the execution trace, and returns a
+// function that stops them and writes the allocation profile.
+func (p *profiles) start() (stop func() error, err error) {
+	var stops []func() error
+	stop = func() error {
+		var errs []error
+		for _, stop := range stops {
+			errs = append(errs, stop())
+		}
+		return errors.Join(errs...)
+	}
+	if p.cpu != "" {
+		file, err := os.Create(p.cpu)
+		if err != nil {
+			return stop, err
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return stop, err
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return file.Close()
+		})
+	}
+	if p.trace != "" {
+		file, err := os.Create(p.trace)
+		if err != nil {
+			return stop, err
+		}
+		if err := trace.Start(file); err != nil {
+			file.Close()
+			return stop, err
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return file.Close()
+		})
+	}
+	if p.mem != "" {
+		stops = append(stops, func() error {
+			file, err := os.Create(p.mem)
+			if err != nil {
+				return err
+			}
+			runtime.GC()
+			if err := pprof.Lookup("allocs").WriteTo(file, 0); err != nil {
+				file.Close()
+				return err
+			}
+			return file.Close()
+		})
+	}
+	return stop, nil
+}
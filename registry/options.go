@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Options holds raw key=value pairs as given with --opt.
+// It implements flag.Value so the flag can be repeated.
+type Options map[string]string
+
+func (opts Options) String() string {
+	keys := make([]string, 0, len(opts))
+	for key := range opts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + opts[key]
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (opts Options) Set(pair string) error {
+	key, value, found := strings.Cut(pair, "=")
+	if !found || key == "" {
+		return fmt.Errorf("option %q is not in key=value format", pair)
+	}
+	opts[key] = value
+	return nil
+}
+
+// Option describes a field of a day's typed options struct.
+// Fields are declared with tags:
+//
+//	Jokers *bool `opt:"jokers" usage:"rank J as a joker"`
+//	Red    int   `opt:"red" default:"12" min:"0" usage:"red cubes in the bag"`
+//
+// Pointer fields stay nil unless set, which lets parts pick their own default.
+// Integers given below min are rejected.
+type Option struct {
+	Name    string
+	Default string
+	Usage   string
+	field   int
+	min     string
+}
+
+func describeOptions(typ reflect.Type) (options []Option) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, found := field.Tag.Lookup("opt")
+		if !found {
+			continue
+		}
+		options = append(options, Option{
+			Name:    name,
+			Default: field.Tag.Get("default"),
+			Usage:   field.Tag.Get("usage"),
+			field:   i,
+			min:     field.Tag.Get("min"),
+		})
+	}
+	return
+}
+
+func setOption(field reflect.Value, str string) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := setOption(value.Elem(), str); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.Bool:
+		value, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int64:
+		value, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(value)
+	case reflect.String:
+		field.SetString(str)
+	default:
+		panic(fmt.Sprintf("unsupported option kind %v", field.Kind()))
+	}
+	return nil
+}
+
+func buildOptions[O any](options []Option, opts Options) (result O, err error) {
+	value := reflect.ValueOf(&result).Elem()
+	for _, option := range options {
+		if option.Default == "" {
+			continue
+		}
+		if err = setOption(value.Field(option.field), option.Default); err != nil {
+			panic(fmt.Sprintf("bad default for option %s: %v", option.Name, err))
+		}
+	}
+	for key, str := range opts {
+		i := findOption(options, key)
+		if i < 0 {
+			return result, fmt.Errorf("unknown option %q", key)
+		}
+		if err = setOption(value.Field(options[i].field), str); err != nil {
+			return result, fmt.Errorf("option %s: %w", key, err)
+		}
+		if err = checkMin(value.Field(options[i].field), options[i].min); err != nil {
+			return result, fmt.Errorf("option %s: %w", key, err)
+		}
+	}
+	return
+}
+
+func checkMin(field reflect.Value, minStr string) error {
+	if minStr == "" {
+		return nil
+	}
+	min, err := strconv.ParseInt(minStr, 10, 64)
+	if err != nil {
+		panic(fmt.Sprintf("bad min %q: %v", minStr, err))
+	}
+	if field.Kind() == reflect.Pointer {
+		field = field.Elem()
+	}
+	if field.Int() < min {
+		return fmt.Errorf("%d is less than %d", field.Int(), min)
+	}
+	return nil
+}
+
+func findOption(options []Option, name string) int {
+	for i, option := range options {
+		if option.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testOptions struct {
+	Flag    *bool  `opt:"flag" usage:"a pointer bool"`
+	Count   int    `opt:"count" default:"3" min:"1" usage:"an int with a default"`
+	Limit   *int   `opt:"limit" min:"0" usage:"a pointer int"`
+	Name    string `opt:"name" default:"rx" usage:"a string"`
+	Ignored int
+}
+
+func TestOptionsSet(t *testing.T) {
+	opts := make(Options)
+	for _, pair := range []string{"b=2", "a=1", "c=x=y", "d="} {
+		if err := opts.Set(pair); err != nil {
+			t.Errorf("%q: %v", pair, err)
+		}
+	}
+	if got, want := opts.String(), "a=1,b=2,c=x=y,d="; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, pair := range []string{"a", "=1", ""} {
+		if err := opts.Set(pair); err == nil {
+			t.Errorf("%q: expected an error", pair)
+		}
+	}
+}
+
+func TestDescribeOptions(t *testing.T) {
+	options := describeOptions(reflect.TypeOf(testOptions{}))
+	var names []string
+	for _, option := range options {
+		names = append(names, option.Name)
+	}
+	if got, want := strings.Join(names, ","), "flag,count,limit,name"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if options[1].Default != "3" || options[1].Usage != "an int with a default" {
+		t.Errorf("got %+v", options[1])
+	}
+}
+
+func TestBuildOptions(t *testing.T) {
+	yes, zero, five := true, 0, 5
+	options := describeOptions(reflect.TypeOf(testOptions{}))
+	for _, test := range []struct {
+		opts Options
+		want testOptions
+	}{
+		{Options{}, testOptions{Count: 3, Name: "rx"}},
+		{Options{"flag": "true"}, testOptions{Flag: &yes, Count: 3, Name: "rx"}},
+		{Options{"count": "1", "name": "out"}, testOptions{Count: 1, Name: "out"}},
+		{Options{"limit": "0"}, testOptions{Count: 3, Limit: &zero, Name: "rx"}},
+		{Options{"limit": "5", "name": ""}, testOptions{Count: 3, Limit: &five}},
+	} {
+		got, err := buildOptions[testOptions](options, test.opts)
+		if err != nil {
+			t.Errorf("%v: %v", test.opts, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v: got %+v, want %+v", test.opts, got, test.want)
+		}
+	}
+}
+
+func TestBuildOptionsErrors(t *testing.T) {
+	options := describeOptions(reflect.TypeOf(testOptions{}))
+	for _, test := range []struct {
+		opts Options
+		want string
+	}{
+		{Options{"ignored": "1"}, `unknown option "ignored"`},
+		{Options{"flag": "maybe"}, `option flag: strconv.ParseBool: parsing "maybe": invalid syntax`},
+		{Options{"count": "x"}, `option count: strconv.ParseInt: parsing "x": invalid syntax`},
+		{Options{"count": "0"}, "option count: 0 is less than 1"},
+		{Options{"limit": "-1"}, "option limit: -1 is less than 0"},
+	} {
+		_, err := buildOptions[testOptions](options, test.opts)
+		if err == nil || err.Error() != test.want {
+			t.Errorf("%v: got %v, want %s", test.opts, err, test.want)
+		}
+	}
+}
+
+func TestKnown(t *testing.T) {
+	day := Day{Options: describeOptions(reflect.TypeOf(testOptions{}))}
+	if got := day.Known(Options{"count": "2", "smudge": "1"}); len(got) != 1 || got["count"] != "2" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestRegisterOptionErrors(t *testing.T) {
+	parse := func(r io.Reader) (Solver, error) { return nil, nil }
+	Register(2000, 1, parse)
+	RegisterWithOptions(2000, 2, func(r io.Reader, opts testOptions) (Solver, error) { return nil, nil })
+	for number, want := range map[int]string{
+		1: `2000 day 1: unknown option "count"`,
+		2: "2000 day 2: option count: 0 is less than 1",
+	} {
+		day, _ := Get(2000, number)
+		if _, err := day.Parse(strings.NewReader(""), Options{"count": "0"}); err == nil || err.Error() != want {
+			t.Errorf("day %d: got %v, want %s", number, err, want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+)
+
+// Solver answers both parts of a parsed puzzle. Long searches should
+// give up with ctx.Err() once the context is done.
+type Solver interface {
+	Part1(ctx context.Context) (int, error)
+	Part2(ctx context.Context) (int, error)
+}
+
+// ErrNoPuzzle is returned by a part that has no puzzle, like the second part
+// of the last day, so that no answer is reported or submitted for it.
+var ErrNoPuzzle = errors.New("no puzzle for this part")
+
+type Day struct {
+	Year    int
+	Number  int
+	Options []Option
+	parse   func(r io.Reader, opts Options) (Solver, error)
+}
+
+// DefaultYear is the year commands work on unless told otherwise.
+const DefaultYear = 2023
+
+func InputPath(year, number int) string {
+	return fmt.Sprintf("input/%d/day%02d.txt", year, number)
+}
+
+func (day Day) DefaultInput() string {
+	return InputPath(day.Year, day.Number)
+}
+
+func (day Day) String() string {
+	return fmt.Sprintf("%d day %02d", day.Year, day.Number)
+}
+
+func (day Day) Parse(r io.Reader, opts Options) (Solver, error) {
+	return day.parse(r, opts)
+}
+
+func (day Day) ParseFile(path string, opts Options) (Solver, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return day.Parse(file, opts)
+}
+
+// Known returns the subset of opts that the day declares.
+func (day Day) Known(opts Options) Options {
+	known := make(Options)
+	for key, value := range opts {
+		if findOption(day.Options, key) >= 0 {
+			known[key] = value
+		}
+	}
+	return known
+}
+
+func (day Day) Solve(ctx context.Context, solver Solver, part int) (int, error) {
+	switch part {
+	case 1:
+		return solver.Part1(ctx)
+	case 2:
+		return solver.Part2(ctx)
+	default:
+		return 0, fmt.Errorf("%v has no part %d", day, part)
+	}
+}
+
+type key struct {
+	year, number int
+}
+
+var days = make(map[key]Day)
+
+func add(day Day) {
+	k := key{day.Year, day.Number}
+	if _, exists := days[k]; exists {
+		panic(fmt.Sprintf("%v is registered twice", day))
+	}
+	days[k] = day
+}
+
+func Register(year, number int, parse func(r io.Reader) (Solver, error)) {
+	add(Day{
+		Year:   year,
+		Number: number,
+		parse: func(r io.Reader, opts Options) (Solver, error) {
+			for key := range opts {
+				return nil, fmt.Errorf("%d day %d: unknown option %q", year, number, key)
+			}
+			return parse(r)
+		},
+	})
+}
+
+func RegisterWithOptions[O any](year, number int, parse func(r io.Reader, opts O) (Solver, error)) {
+	options := describeOptions(reflect.TypeOf((*O)(nil)).Elem())
+	add(Day{
+		Year:    year,
+		Number:  number,
+		Options: options,
+		parse: func(r io.Reader, opts Options) (Solver, error) {
+			typed, err := buildOptions[O](options, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%d day %d: %w", year, number, err)
+			}
+			return parse(r, typed)
+		},
+	})
+}
+
+func Get(year, number int) (Day, bool) {
+	day, found := days[key{year, number}]
+	return day, found
+}
+
+// All lists the days of every year in order.
+func All() []Day {
+	all := make([]Day, 0, len(days))
+	for _, day := range days {
+		all = append(all, day)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Year != all[j].Year {
+			return all[i].Year < all[j].Year
+		}
+		return all[i].Number < all[j].Number
+	})
+	return all
+}
+
+// Year lists the days of the year in order.
+func Year(year int) (result []Day) {
+	for _, day := range All() {
+		if day.Year == year {
+			result = append(result, day)
+		}
+	}
+	return
+}
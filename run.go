@@ -0,0 +1,224 @@
+package main
+
+import (
+	"advent/cache"
+	"advent/registry"
+	"advent/runner"
+	"advent/utils/graph"
+	"advent/utils/logger"
+	"advent/utils/render"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+)
+
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	dayNum := flags.Int("day", 0, "day to run")
+	year := flags.Int("year", registry.DefaultYear, "puzzle year")
+	part := flags.Int("part", 0, "part to run (default: both)")
+	input := flags.String("input", "", "input file, - for stdin (default: input/YEAR/dayNN.txt)")
+	all := flags.Bool("all", false, "run every registered day of the year")
+	parallel := flags.Int("parallel", 1, "how many days to run at once")
+	timeout := flags.Duration("timeout", 0, "give up on a day after this long (default: never)")
+	renderTo := flags.String("render", "", "draw the puzzle to FILE as .svg, .png or ANSI text")
+	exportTo := flags.String("export-graph", "", "write the puzzle graph to FORMAT:PATH, FORMAT is dot, mermaid, graphml or json")
+	verbose := flags.Bool("verbose", false, "log what the solvers are doing, same as --log-level debug")
+	logLevel := flags.String("log-level", "", "log at LEVEL and above, trace includes whole grids and states")
+	logTo := flags.String("log", "", "write the log to FILE as JSON lines instead of stderr")
+	noCache := flags.Bool("no-cache", false, "solve again even if the answers are cached")
+	format := flags.String("format", "text", "print the results as text, json, csv or markdown")
+	opts := make(registry.Options)
+	flags.Var(opts, "opt", "puzzle option as key=value, can be repeated (see advent list)")
+	var profiles profiles
+	profiles.addFlags(flags)
+	flags.Parse(args)
+
+	if *verbose && *logLevel == "" {
+		*logLevel = "debug"
+	}
+	ctx, closeLog, err := withLogger(context.Background(), *logLevel, *logTo)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	parts, err := selectParts(*part)
+	if err != nil {
+		return err
+	}
+	newWriter, found := runner.Formats[*format]
+	if !found {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if *exportTo != "" {
+		if _, _, err := graph.ParseTarget(*exportTo); err != nil {
+			return err
+		}
+	}
+	illustrate := *renderTo != "" || *exportTo != ""
+
+	var jobs []runner.Job
+	if *all {
+		if *dayNum != 0 || *input != "" || illustrate {
+			return errors.New("--all cannot be combined with --day, --input, --render or --export-graph")
+		}
+		for _, day := range registry.Year(*year) {
+			jobs = append(jobs, runner.Job{Day: day, Input: day.DefaultInput(), Opts: day.Known(opts), Parts: parts})
+		}
+		// Each day only gets the options it knows, so catch typos here.
+		for _, key := range slices.Sorted(maps.Keys(opts)) {
+			if !slices.ContainsFunc(jobs, func(job runner.Job) bool {
+				_, found := job.Opts[key]
+				return found
+			}) {
+				return fmt.Errorf("no day of %d has option %q", *year, key)
+			}
+		}
+	} else {
+		day, found := registry.Get(*year, *dayNum)
+		if !found {
+			return fmt.Errorf("%d day %d is not registered", *year, *dayNum)
+		}
+		if *input == "" {
+			*input = day.DefaultInput()
+		}
+		if illustrate && *input == "-" {
+			return errors.New("--render and --export-graph cannot read the input from stdin")
+		}
+		jobs = append(jobs, runner.Job{Day: day, Input: *input, Opts: opts, Parts: parts})
+	}
+
+	if !*noCache {
+		if err := useCache(jobs); err != nil {
+			return err
+		}
+	}
+
+	stopProfiles, err := profiles.start()
+	if err != nil {
+		stopProfiles()
+		return err
+	}
+	var errs []error
+	writer := newWriter(os.Stdout)
+	runner.Run(ctx, jobs, *parallel, *timeout, func(result runner.Result) {
+		for _, row := range runner.Rows(result) {
+			if err := writer.Write(row); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", result.Job.Day, result.Err))
+		}
+	})
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := stopProfiles(); err != nil {
+		return err
+	}
+	if len(jobs) == 1 && len(errs) == 1 {
+		return errs[0]
+	}
+	if illustrate {
+		return illustrateDay(jobs[0], *renderTo, *exportTo)
+	}
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d days failed", len(errs), len(jobs))
+	}
+	return nil
+}
+
+// useCache lets the jobs look up answers computed by the same solver
+// code for the same input.
+func useCache(jobs []runner.Job) error {
+	answers, err := cache.Open()
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		if jobs[i].Version, err = solverVersion(jobs[i].Day); err != nil {
+			return err
+		}
+		jobs[i].Cache = answers
+	}
+	return nil
+}
+
+// withLogger sets up the solvers' log. Normal runs only print the answers,
+// and --log without a level keeps the debug records.
+func withLogger(ctx context.Context, levelName string, path string) (context.Context, func() error, error) {
+	level := slog.LevelInfo
+	if levelName == "" && path != "" {
+		levelName = "debug"
+	}
+	if levelName != "" {
+		var err error
+		if level, err = logger.ParseLevel(levelName); err != nil {
+			return nil, nil, err
+		}
+	}
+	if path == "" {
+		return logger.With(ctx, logger.New(os.Stderr, level, false)), func() error { return nil }, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return logger.With(ctx, logger.New(file, level, true)), file.Close, nil
+}
+
+// illustrateDay parses the input again to draw the puzzle to renderTo and
+// export its graph to exportTo, skipping whichever is empty.
+func illustrateDay(job runner.Job, renderTo, exportTo string) error {
+	solver, err := job.Day.ParseFile(job.Input, job.Opts)
+	if err != nil {
+		return err
+	}
+	if renderTo != "" {
+		drawable, ok := solver.(render.Drawable)
+		if !ok {
+			return fmt.Errorf("%v cannot be rendered", job.Day)
+		}
+		if err := render.WriteFile(renderTo, drawable.Draw()); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "wrote", renderTo)
+	}
+	if exportTo != "" {
+		exportable, ok := solver.(graph.Exportable)
+		if !ok {
+			return fmt.Errorf("%v has no graph to export", job.Day)
+		}
+		write, path, err := graph.ParseTarget(exportTo)
+		if err != nil {
+			return err
+		}
+		if err := graph.WriteFile(write, path, exportable.ExportGraph()); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "wrote", path)
+	}
+	return nil
+}
+
+// selectParts turns the --part flag into the list of parts to solve.
+func selectParts(part int) ([]int, error) {
+	switch part {
+	case 0:
+		return []int{1, 2}, nil
+	case 1, 2:
+		return []int{part}, nil
+	default:
+		return nil, fmt.Errorf("there is no part %d", part)
+	}
+}
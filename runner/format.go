@@ -0,0 +1,204 @@
+package runner
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Row describes how one part of a day went, for reports meant to be read
+// by other programs or pasted into the README.
+type Row struct {
+	Year    int           `json:"year"`
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  *int          `json:"answer"`
+	Input   string        `json:"input"`
+	Options string        `json:"options"`
+	Parse   time.Duration `json:"-"`
+	Elapsed time.Duration `json:"-"`
+	Status  string        `json:"status"` // ok, cached, n/a, timeout, panic or error
+	Error   string        `json:"error,omitempty"`
+}
+
+func status(err error) string {
+	var timeout *TimeoutError
+	var panicked *PanicError
+	switch {
+	case errors.As(err, &timeout):
+		return "timeout"
+	case errors.As(err, &panicked):
+		return "panic"
+	default:
+		return "error"
+	}
+}
+
+// Rows lists every part of the job, including the ones the error kept
+// from being answered.
+func Rows(result Result) (rows []Row) {
+	for _, part := range result.Job.Parts {
+		row := Row{
+			Year:    result.Job.Day.Year,
+			Day:     result.Job.Day.Number,
+			Part:    part,
+			Input:   result.Job.Input,
+			Options: result.Job.Opts.String(),
+			Parse:   result.Parsed,
+		}
+		if i := slices.IndexFunc(result.Answers, func(answer Answer) bool { return answer.Part == part }); i >= 0 {
+			answer := result.Answers[i]
+			row.Answer, row.Elapsed, row.Status = &answer.Value, answer.Elapsed, "ok"
+			switch {
+			case answer.NoPuzzle:
+				row.Answer, row.Status = nil, "n/a"
+			case answer.Cached:
+				row.Status = "cached"
+			}
+		} else if result.Err != nil {
+			row.Status, row.Error = status(result.Err), result.Err.Error()
+		}
+		rows = append(rows, row)
+	}
+	return
+}
+
+// Writer reports rows as they come and finishes the report on Close.
+type Writer interface {
+	Write(row Row) error
+	Close() error
+}
+
+var Formats = map[string]func(w io.Writer) Writer{
+	"text":     func(w io.Writer) Writer { return &textWriter{w} },
+	"json":     func(w io.Writer) Writer { return &jsonWriter{w: w} },
+	"csv":      func(w io.Writer) Writer { return &csvWriter{w: csv.NewWriter(w)} },
+	"markdown": func(w io.Writer) Writer { return &markdownWriter{w: w} },
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// textWriter prints answers only, failures are up to the caller.
+type textWriter struct {
+	w io.Writer
+}
+
+func (tw *textWriter) Write(row Row) error {
+	if row.Status == "n/a" {
+		_, err := fmt.Fprintf(tw.w, "%d day %02d part %d: not applicable\n", row.Year, row.Day, row.Part)
+		return err
+	}
+	if row.Answer == nil {
+		return nil
+	}
+	cached := ""
+	if row.Status == "cached" {
+		cached = " (cached)"
+	}
+	_, err := fmt.Fprintf(tw.w, "%d day %02d part %d: %d%s\n", row.Year, row.Day, row.Part, *row.Answer, cached)
+	return err
+}
+
+func (tw *textWriter) Close() error {
+	return nil
+}
+
+type jsonRow struct {
+	Row
+	ParseMS   float64 `json:"parse_ms"`
+	ElapsedMS float64 `json:"duration_ms"`
+}
+
+type jsonWriter struct {
+	w    io.Writer
+	rows []jsonRow
+}
+
+func (jw *jsonWriter) Write(row Row) error {
+	jw.rows = append(jw.rows, jsonRow{row, milliseconds(row.Parse), milliseconds(row.Elapsed)})
+	return nil
+}
+
+func (jw *jsonWriter) Close() error {
+	if jw.rows == nil {
+		jw.rows = []jsonRow{}
+	}
+	encoder := json.NewEncoder(jw.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jw.rows)
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (cw *csvWriter) Write(row Row) error {
+	if !cw.header {
+		cw.header = true
+		cw.w.Write([]string{"year", "day", "part", "answer", "input", "options", "parse_ms", "duration_ms", "status", "error"})
+	}
+	answer := ""
+	if row.Answer != nil {
+		answer = strconv.Itoa(*row.Answer)
+	}
+	return cw.w.Write([]string{
+		strconv.Itoa(row.Year),
+		strconv.Itoa(row.Day),
+		strconv.Itoa(row.Part),
+		answer,
+		row.Input,
+		row.Options,
+		strconv.FormatFloat(milliseconds(row.Parse), 'f', 3, 64),
+		strconv.FormatFloat(milliseconds(row.Elapsed), 'f', 3, 64),
+		row.Status,
+		row.Error,
+	})
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+type markdownWriter struct {
+	w      io.Writer
+	header bool
+}
+
+// escapeCell keeps pipes and line breaks from ending the table cell.
+func escapeCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+func (mw *markdownWriter) Write(row Row) error {
+	if !mw.header {
+		mw.header = true
+		fmt.Fprintln(mw.w, "| Year | Day | Part | Answer | Input | Options | Parse | Time | Status |")
+		fmt.Fprintln(mw.w, "|-----:|----:|-----:|-------:|-------|---------|------:|-----:|--------|")
+	}
+	answer := ""
+	if row.Answer != nil {
+		answer = strconv.Itoa(*row.Answer)
+	}
+	status := row.Status
+	if row.Error != "" {
+		status += ": " + row.Error
+	}
+	_, err := fmt.Fprintf(mw.w, "| %d | %d | %d | %s | %s | %s | %v | %v | %s |\n",
+		row.Year, row.Day, row.Part, answer, escapeCell(row.Input), escapeCell(row.Options),
+		row.Parse.Round(time.Microsecond), row.Elapsed.Round(time.Microsecond), escapeCell(status))
+	return err
+}
+
+func (mw *markdownWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func results() []Result {
+	return []Result{
+		{
+			Job:     Job{Day: quick, Input: "input/day101.txt", Opts: map[string]string{"size": "2"}, Parts: []int{1, 2}},
+			Answers: []Answer{{Part: 1, Value: 1, Elapsed: time.Millisecond}, {Part: 2, Value: 2, Cached: true}},
+			Parsed:  2 * time.Millisecond,
+		},
+		{
+			Job:     Job{Day: slow, Input: "input/day102.txt", Parts: []int{1, 2}},
+			Answers: []Answer{{Part: 1, Value: 1}},
+			Err:     &TimeoutError{time.Second},
+		},
+		{
+			Job:     Job{Day: last, Input: "input/day107.txt", Parts: []int{1, 2}},
+			Answers: []Answer{{Part: 1, Value: 3}, {Part: 2, NoPuzzle: true}},
+		},
+	}
+}
+
+func format(t *testing.T, name string) string {
+	t.Helper()
+	var out strings.Builder
+	writer := Formats[name](&out)
+	for _, result := range results() {
+		for _, row := range Rows(result) {
+			if err := writer.Write(row); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func TestRows(t *testing.T) {
+	var statuses []string
+	for _, result := range results() {
+		for _, row := range Rows(result) {
+			statuses = append(statuses, row.Status)
+		}
+	}
+	if got := strings.Join(statuses, " "); got != "ok cached ok timeout ok n/a" {
+		t.Errorf("got statuses %s", got)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	if got, want := format(t, "text"), "2000 day 101 part 1: 1\n2000 day 101 part 2: 2 (cached)\n2000 day 102 part 1: 1\n2000 day 107 part 1: 3\n2000 day 107 part 2: not applicable\n"; got != want {
+		t.Errorf("text: got %q, want %q", got, want)
+	}
+
+	var rows []map[string]any
+	if err := json.Unmarshal([]byte(format(t, "json")), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 6 || rows[0]["options"] != "size=2" || rows[0]["duration_ms"] != 1.0 || rows[3]["answer"] != nil || rows[3]["error"] != "timed out after 1s" || rows[5]["answer"] != nil || rows[5]["status"] != "n/a" {
+		t.Errorf("json: got %v", rows)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(format(t, "csv"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 7 || records[0][3] != "answer" || records[1][6] != "2.000" || records[4][8] != "timeout" || records[6][8] != "n/a" {
+		t.Errorf("csv: got %v", records)
+	}
+
+	lines := strings.Split(strings.TrimSpace(format(t, "markdown")), "\n")
+	if len(lines) != 8 || lines[2] != "| 2000 | 101 | 1 | 1 | input/day101.txt | size=2 | 2ms | 1ms | ok |" {
+		t.Errorf("markdown: got\n%s", strings.Join(lines, "\n"))
+	}
+}
@@ -0,0 +1,237 @@
+package runner
+
+import (
+	"advent/cache"
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/logger"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+)
+
+type Job struct {
+	Day   registry.Day
+	Input string // - for stdin
+	// Data is the input itself when it does not come from a file, and
+	// Input only names it in parse errors.
+	Data  []byte
+	Opts  registry.Options
+	Parts []int
+	// Cache is consulted before solving when set, with answers keyed
+	// by the version of the solver among other things.
+	Cache   *cache.Cache
+	Version string
+	// Finished is called when the solver stops, which can be long after
+	// a timed out job has been reported.
+	Finished func()
+}
+
+type Answer struct {
+	Part    int
+	Value   int
+	Cached  bool
+	Elapsed time.Duration
+	// NoPuzzle marks a part without a puzzle, which has no Value.
+	NoPuzzle bool
+}
+
+// Result holds the answers found before the job failed, if it did.
+type Result struct {
+	Job     Job
+	Answers []Answer
+	Parsed  time.Duration // zero if every answer was cached
+	Err     error
+}
+
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %v", e.Timeout)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+func readInput(job Job) ([]byte, error) {
+	if job.Data != nil {
+		return job.Data, nil
+	}
+	if job.Input == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(job.Input)
+}
+
+// parse reads the input unless it has been read already for the cache.
+func parse(job Job, data []byte) (registry.Solver, error) {
+	if data != nil {
+		name := job.Input
+		if name == "-" {
+			name = os.Stdin.Name()
+		}
+		return job.Day.Parse(utils.Named(name, bytes.NewReader(data)), job.Opts)
+	}
+	if job.Input == "-" {
+		return job.Day.Parse(os.Stdin, job.Opts)
+	}
+	return job.Day.ParseFile(job.Input, job.Opts)
+}
+
+func solve(ctx context.Context, job Job, answers chan<- Answer, parsed chan<- time.Duration) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{r, debug.Stack()}
+		}
+	}()
+	ctx, task := trace.NewTask(ctx, job.Day.String())
+	defer task.End()
+	log := logger.From(ctx).With("year", job.Day.Year, "day", job.Day.Number)
+	var data []byte
+	if job.Cache != nil || job.Data != nil {
+		if data, err = readInput(job); err != nil {
+			return err
+		}
+	}
+	var solver registry.Solver
+	for _, part := range job.Parts {
+		partLog := log.With("part", part)
+		var key string
+		if job.Cache != nil {
+			key = cache.Key(data, job.Day.Year, job.Day.Number, part, job.Opts.String(), job.Version)
+			if value, found := job.Cache.Get(key); found {
+				partLog.Debug("cached", "key", key)
+				answers <- Answer{Part: part, Value: value, Cached: true}
+				continue
+			}
+		}
+		if solver == nil {
+			start := time.Now()
+			phase(ctx, job, "parse", func(ctx context.Context) {
+				solver, err = parse(job, data)
+			})
+			if err != nil {
+				return err
+			}
+			elapsed := time.Since(start)
+			parsed <- elapsed
+			log.Debug("parsed", "input", job.Input, "elapsed", elapsed)
+		}
+		start := time.Now()
+		var value int
+		phase(ctx, job, fmt.Sprintf("part%d", part), func(ctx context.Context) {
+			value, err = job.Day.Solve(logger.With(ctx, partLog), solver, part)
+		})
+		if errors.Is(err, registry.ErrNoPuzzle) {
+			answers <- Answer{Part: part, NoPuzzle: true}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		elapsed := time.Since(start)
+		partLog.Debug("solved", "elapsed", elapsed)
+		if job.Cache != nil {
+			if err := job.Cache.Put(key, value); err != nil {
+				partLog.Warn("answer not cached", "err", err)
+			}
+		}
+		answers <- Answer{Part: part, Value: value, Elapsed: elapsed}
+	}
+	return nil
+}
+
+// phase labels the profile samples and the trace region of f with the year,
+// the day and the phase, which is parse, part1 or part2.
+func phase(ctx context.Context, job Job, name string, f func(context.Context)) {
+	labels := pprof.Labels("year", fmt.Sprint(job.Day.Year), "day", fmt.Sprintf("%02d", job.Day.Number), "phase", name)
+	pprof.Do(ctx, labels, func(ctx context.Context) {
+		trace.WithRegion(ctx, name, func() {
+			f(ctx)
+		})
+	})
+}
+
+// runJob gives up on the job once its context is done, even if the solver
+// does not check the context and keeps running in the background.
+func runJob(ctx context.Context, job Job, timeout time.Duration) (result Result) {
+	result.Job = job
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	answers := make(chan Answer, len(job.Parts))
+	parsed := make(chan time.Duration, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- solve(ctx, job, answers, parsed)
+		if job.Finished != nil {
+			job.Finished()
+		}
+	}()
+wait:
+	for {
+		select {
+		case result.Parsed = <-parsed:
+		case answer := <-answers:
+			result.Answers = append(result.Answers, answer)
+		case result.Err = <-done:
+			break wait
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+			break wait
+		}
+	}
+	if len(parsed) > 0 {
+		result.Parsed = <-parsed
+	}
+	for len(answers) > 0 {
+		result.Answers = append(result.Answers, <-answers)
+	}
+	if errors.Is(result.Err, context.DeadlineExceeded) && timeout > 0 {
+		result.Err = &TimeoutError{timeout}
+	}
+	return
+}
+
+// Run solves up to parallel jobs at a time and reports every result in the
+// order of jobs.
+func Run(ctx context.Context, jobs []Job, parallel int, timeout time.Duration, report func(Result)) {
+	results := make([]chan Result, len(jobs))
+	for i := range results {
+		results[i] = make(chan Result, 1)
+	}
+	slots := make(chan struct{}, max(parallel, 1))
+	go func() {
+		for i, job := range jobs {
+			slots <- struct{}{}
+			go func(result chan<- Result, job Job) {
+				result <- runJob(ctx, job, timeout)
+				<-slots
+			}(results[i], job)
+		}
+	}()
+	for i := range jobs {
+		report(<-results[i])
+	}
+}
@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"advent/cache"
+	"advent/registry"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"testing"
+	"time"
+)
+
+type fake struct {
+	part1, part2 func(ctx context.Context) (int, error)
+}
+
+func (f fake) Part1(ctx context.Context) (int, error) { return f.part1(ctx) }
+func (f fake) Part2(ctx context.Context) (int, error) { return f.part2(ctx) }
+
+func answer(value int) func(ctx context.Context) (int, error) {
+	return func(ctx context.Context) (int, error) { return value, nil }
+}
+
+// fakes are registered in a year that has no real puzzles.
+const year = 2000
+
+func register(number int, solver fake) registry.Day {
+	registry.Register(year, number, func(r io.Reader) (registry.Solver, error) {
+		return solver, nil
+	})
+	day, _ := registry.Get(year, number)
+	return day
+}
+
+var solves int
+
+var (
+	quick = register(101, fake{answer(1), answer(2)})
+	slow  = register(102, fake{answer(1), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}})
+	stuck = register(103, fake{func(ctx context.Context) (int, error) {
+		select {}
+	}, answer(2)})
+	broken = register(104, fake{answer(1), func(ctx context.Context) (int, error) {
+		panic("broken")
+	}})
+	counted = register(106, fake{func(ctx context.Context) (int, error) {
+		solves++
+		return 1, nil
+	}, answer(2)})
+	last = register(107, fake{answer(1), func(ctx context.Context) (int, error) {
+		return 0, registry.ErrNoPuzzle
+	}})
+	labelled = register(105, fake{answer(1), func(ctx context.Context) (int, error) {
+		day, _ := pprof.Label(ctx, "day")
+		phase, _ := pprof.Label(ctx, "phase")
+		if day != "105" || phase != "part2" {
+			return 0, fmt.Errorf("labelled day %q, phase %q", day, phase)
+		}
+		return 2, nil
+	}})
+)
+
+func TestRun(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(input, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var jobs []Job
+	for _, day := range []registry.Day{stuck, slow, broken, quick} {
+		jobs = append(jobs, Job{Day: day, Input: input, Parts: []int{1, 2}})
+	}
+
+	var results []Result
+	Run(context.Background(), jobs, 2, 50*time.Millisecond, func(result Result) {
+		results = append(results, result)
+	})
+
+	if len(results) != len(jobs) {
+		t.Fatalf("got %d results, want %d", len(results), len(jobs))
+	}
+	for i, result := range results {
+		if result.Job.Day.Number != jobs[i].Day.Number {
+			t.Errorf("result %d is for day %d, want day %d", i, result.Job.Day.Number, jobs[i].Day.Number)
+		}
+	}
+	var timeout *TimeoutError
+	if !errors.As(results[0].Err, &timeout) || len(results[0].Answers) != 0 {
+		t.Errorf("stuck day: got %v, %v", results[0].Answers, results[0].Err)
+	}
+	if !errors.As(results[1].Err, &timeout) || len(results[1].Answers) != 1 {
+		t.Errorf("slow day: got %v, %v", results[1].Answers, results[1].Err)
+	}
+	var panicked *PanicError
+	if !errors.As(results[2].Err, &panicked) || len(results[2].Answers) != 1 {
+		t.Errorf("broken day: got %v, %v", results[2].Answers, results[2].Err)
+	}
+	if results[3].Err != nil || len(results[3].Answers) != 2 {
+		t.Errorf("quick day: got %v, %v", results[3].Answers, results[3].Err)
+	}
+}
+
+func TestRunNoPuzzle(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(input, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	Run(context.Background(), []Job{{Day: last, Input: input, Parts: []int{1, 2}}}, 1, 0, func(result Result) {
+		if result.Err != nil || len(result.Answers) != 2 || result.Answers[0].NoPuzzle || !result.Answers[1].NoPuzzle {
+			t.Errorf("got %v, %v", result.Answers, result.Err)
+		}
+	})
+}
+
+func TestRunLabels(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(input, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	Run(context.Background(), []Job{{Day: labelled, Input: input, Parts: []int{2}}}, 1, 0, func(result Result) {
+		if result.Err != nil {
+			t.Error(result.Err)
+		}
+	})
+}
+
+func TestRunCached(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(input, []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	job := Job{Day: counted, Input: input, Parts: []int{1, 2}, Cache: &cache.Cache{Dir: t.TempDir()}, Version: "v1"}
+	run := func() (answers []Answer) {
+		Run(context.Background(), []Job{job}, 1, 0, func(result Result) {
+			if result.Err != nil {
+				t.Error(result.Err)
+			}
+			answers = result.Answers
+		})
+		return
+	}
+
+	solves = 0
+	if answers := run(); len(answers) != 2 || answers[0].Cached || answers[1].Cached {
+		t.Errorf("first run: got %v", answers)
+	}
+	if answers := run(); len(answers) != 2 || !answers[0].Cached || answers[0].Value != 1 || !answers[1].Cached {
+		t.Errorf("second run: got %v", answers)
+	}
+	job.Version = "v2"
+	if answers := run(); len(answers) != 2 || answers[0].Cached {
+		t.Errorf("new version: got %v", answers)
+	}
+	if solves != 2 {
+		t.Errorf("solved part 1 %d times, want 2", solves)
+	}
+}
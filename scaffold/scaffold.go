@@ -0,0 +1,184 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+var solverTemplate = template.Must(template.New("solver").Parse(`package {{.Package}}
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"io"
+)
+
+type Lines []string
+
+func (lines Lines) Part1(ctx context.Context) (int, error) {
+	return len(lines), nil
+}
+
+func (lines Lines) Part2(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func parseLine(line string) (string, error) {
+	return line, nil
+}
+
+func aggregate(lines Lines, line string) Lines {
+	return append(lines, line)
+}
+
+func init() {
+	registry.Register({{.Year}}, {{.Number}}, Parse)
+}
+
+func Parse(r io.Reader) (registry.Solver, error) {
+	return utils.ProcessReader(r, Lines{}, parseLine, aggregate)
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`package {{.Package}}
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, {{.Year}}, {{.Number}},
+		golden.Case{Input: "{{.Package}}_test.txt"},
+	)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, {{.Year}}, {{.Number}})
+}
+`))
+
+// FirstYear is when Advent of Code started.
+const FirstYear = 2015
+
+type file struct {
+	path    string
+	content []byte
+}
+
+func render(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// register adds a blank import of the package to days/days.go, leaving
+// the file alone if the import is already there.
+func register(root, importPath string) (*file, error) {
+	path := filepath.Join(root, "days", "days.go")
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := fmt.Sprintf("_ %q", importPath)
+	if bytes.Contains(source, []byte(spec)) {
+		return nil, nil
+	}
+	end := bytes.LastIndex(source, []byte("\n)"))
+	if end < 0 {
+		return nil, fmt.Errorf("%s: no import block", path)
+	}
+	edited := append([]byte{}, source[:end]...)
+	edited = append(edited, "\n\t"+spec...)
+	edited = append(edited, source[end:]...)
+	if edited, err = format.Source(edited); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &file{path, edited}, nil
+}
+
+func exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// New generates a solver package for the day under root/yYEAR, together
+// with a golden test stub, its registration and empty input files under
+// input/YEAR. Nothing is written if the package already exists, and
+// existing inputs are kept. It returns the paths it wrote.
+func New(root string, year, number int) ([]string, error) {
+	if year < FirstYear {
+		return nil, fmt.Errorf("invalid year %d", year)
+	}
+	if number < 1 {
+		return nil, fmt.Errorf("invalid day %d", number)
+	}
+	pkg := fmt.Sprintf("day%02d", number)
+	yearDir := fmt.Sprintf("y%d", year)
+	dir := filepath.Join(root, yearDir, pkg)
+	if found, err := exists(dir); err != nil {
+		return nil, err
+	} else if found {
+		return nil, fmt.Errorf("%s already exists", dir)
+	}
+
+	data := struct {
+		Package string
+		Year    int
+		Number  int
+	}{pkg, year, number}
+	solver, err := render(solverTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	test, err := render(testTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	files := []file{
+		{filepath.Join(dir, pkg+".go"), solver},
+		{filepath.Join(dir, pkg+"_test.go"), test},
+	}
+	days, err := register(root, "advent/"+yearDir+"/"+pkg)
+	if err != nil {
+		return nil, err
+	}
+	if days != nil {
+		files = append(files, *days)
+	}
+	inputDir := filepath.Join(root, "input", fmt.Sprint(year))
+	for _, name := range []string{pkg + "_test.txt", pkg + ".txt"} {
+		path := filepath.Join(inputDir, name)
+		if found, err := exists(path); err != nil {
+			return nil, err
+		} else if !found {
+			files = append(files, file{path, nil})
+		}
+	}
+
+	for _, d := range []string{dir, inputDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	written := make([]string, 0, len(files))
+	for _, f := range files {
+		if err := os.WriteFile(f.path, f.content, 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, f.path)
+	}
+	return written, nil
+}
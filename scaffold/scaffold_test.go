@@ -0,0 +1,54 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const days = `package days
+
+import (
+	"advent/registry"
+	_ "advent/y2023/day01"
+)
+`
+
+func TestNew(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"days", "input/2022"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "days", "days.go"), []byte(days), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	real := filepath.Join(root, "input", "2022", "day02.txt")
+	if err := os.WriteFile(real, []byte("puzzle"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := New(root, 2022, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != 4 {
+		t.Errorf("wrote %v, want 4 files", written)
+	}
+	registered, _ := os.ReadFile(filepath.Join(root, "days", "days.go"))
+	if !strings.Contains(string(registered), `_ "advent/y2022/day02"`) {
+		t.Errorf("day02 is not registered:\n%s", registered)
+	}
+	solver, _ := os.ReadFile(filepath.Join(root, "y2022", "day02", "day02.go"))
+	if !strings.Contains(string(solver), "registry.Register(2022, 2, Parse)") {
+		t.Errorf("solver is registered as:\n%s", solver)
+	}
+	if input, _ := os.ReadFile(real); string(input) != "puzzle" {
+		t.Errorf("real input was overwritten with %q", input)
+	}
+	if _, err := New(root, 2022, 2); err == nil {
+		t.Error("existing package was overwritten")
+	}
+}
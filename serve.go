@@ -0,0 +1,53 @@
+package main
+
+import (
+	"advent/server"
+	"advent/utils/logger"
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+)
+
+func serveCommand(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	maxInput := flags.Int64("max-input", 1<<20, "largest input in bytes a request can post")
+	timeout := flags.Duration("timeout", 30*time.Second, "give up on a request after this long, 0 for never")
+	maxSolves := flags.Int("max-solves", runtime.NumCPU(), "solvers running at once before requests get 503, 0 for no limit")
+	verbose := flags.Bool("verbose", false, "log what the solvers are doing, same as --log-level debug")
+	logLevel := flags.String("log-level", "", "log at LEVEL and above")
+	logTo := flags.String("log", "", "write the log to FILE as JSON lines instead of stderr")
+	flags.Parse(args)
+
+	if *verbose && *logLevel == "" {
+		*logLevel = "debug"
+	}
+	ctx, closeLog, err := withLogger(context.Background(), *logLevel, *logTo)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	log := logger.From(ctx)
+	handler := (&server.Server{MaxInput: *maxInput, Timeout: *timeout, MaxSolves: *maxSolves, Log: log}).Handler()
+	httpServer := &http.Server{Addr: *addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdown <- httpServer.Shutdown(ctx)
+	}()
+	log.Info("listening", "addr", *addr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return <-shutdown
+}
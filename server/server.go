@@ -0,0 +1,207 @@
+package server
+
+import (
+	"advent/registry"
+	"advent/runner"
+	"advent/utils"
+	"advent/utils/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Server solves puzzles posted over HTTP with the same runner as the CLI.
+type Server struct {
+	MaxInput int64         // bytes of input accepted per request
+	Timeout  time.Duration // zero to wait for the solver as long as the client does
+	// MaxSolves limits the solvers running at once, including the ones
+	// still running after their request timed out. Zero means no limit.
+	MaxSolves int
+	Log       *slog.Logger
+
+	slots chan struct{}
+}
+
+type Option struct {
+	Name    string `json:"name"`
+	Default string `json:"default,omitempty"`
+	Usage   string `json:"usage"`
+}
+
+type Day struct {
+	Year    int      `json:"year"`
+	Day     int      `json:"day"`
+	Options []Option `json:"options"`
+}
+
+type Timings struct {
+	ParseMS float64 `json:"parse_ms"`
+	SolveMS float64 `json:"solve_ms"`
+}
+
+// Diagnostic points at the line of the input that failed to parse.
+type Diagnostic struct {
+	Input   string `json:"input"`
+	Line    int    `json:"line"`
+	Text    string `json:"text,omitempty"`
+	Message string `json:"message"`
+}
+
+type Answer struct {
+	Year        int              `json:"year"`
+	Day         int              `json:"day"`
+	Part        int              `json:"part"`
+	Options     registry.Options `json:"options"`
+	Answer      *int             `json:"answer,omitempty"`
+	Timings     Timings          `json:"timings"`
+	Error       string           `json:"error,omitempty"`
+	Diagnostics []Diagnostic     `json:"diagnostics,omitempty"`
+}
+
+func (server *Server) Handler() http.Handler {
+	if server.MaxSolves > 0 {
+		server.slots = make(chan struct{}, server.MaxSolves)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /days", server.listDays)
+	mux.HandleFunc("POST /days/{day}/parts/{part}", server.solve)
+	mux.HandleFunc("POST /years/{year}/days/{day}/parts/{part}", server.solve)
+	return mux
+}
+
+func (server *Server) listDays(w http.ResponseWriter, r *http.Request) {
+	days := []Day{}
+	for _, day := range registry.All() {
+		options := []Option{}
+		for _, option := range day.Options {
+			options = append(options, Option{option.Name, option.Default, option.Usage})
+		}
+		days = append(days, Day{day.Year, day.Number, options})
+	}
+	writeJSON(w, http.StatusOK, days)
+}
+
+// solve answers for the default year unless the path names another.
+func (server *Server) solve(w http.ResponseWriter, r *http.Request) {
+	year := registry.DefaultYear
+	if r.PathValue("year") != "" {
+		var err error
+		if year, err = strconv.Atoi(r.PathValue("year")); err != nil {
+			writeError(w, http.StatusNotFound, fmt.Errorf("year %q is not a number", r.PathValue("year")))
+			return
+		}
+	}
+	number, err := strconv.Atoi(r.PathValue("day"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("day %q is not a number", r.PathValue("day")))
+		return
+	}
+	day, found := registry.Get(year, number)
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%d day %d is not registered", year, number))
+		return
+	}
+	part, err := strconv.Atoi(r.PathValue("part"))
+	if err != nil || (part != 1 && part != 2) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("there is no part %q", r.PathValue("part")))
+		return
+	}
+	opts := make(registry.Options)
+	for key, values := range r.URL.Query() {
+		opts[key] = values[len(values)-1]
+	}
+	if known := day.Known(opts); len(known) != len(opts) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("day %d only knows options %v", number, optionNames(day)))
+		return
+	}
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, server.MaxInput))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("input is larger than %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx := r.Context()
+	if server.Log != nil {
+		ctx = logger.With(ctx, server.Log)
+	}
+	job := runner.Job{Day: day, Input: "input", Data: input, Opts: opts, Parts: []int{part}}
+	if slots := server.slots; slots != nil {
+		select {
+		case slots <- struct{}{}:
+			job.Finished = func() { <-slots }
+		default:
+			writeError(w, http.StatusServiceUnavailable, fmt.Errorf("already solving %d puzzles, try again later", server.MaxSolves))
+			return
+		}
+	}
+	var result runner.Result
+	runner.Run(ctx, []runner.Job{job}, 1, server.Timeout, func(r runner.Result) {
+		result = r
+	})
+
+	answer := Answer{Year: year, Day: number, Part: part, Options: opts}
+	answer.Timings.ParseMS = milliseconds(result.Parsed)
+	status := http.StatusOK
+	if len(result.Answers) > 0 && result.Answers[0].NoPuzzle {
+		status, answer.Error = http.StatusNotFound, registry.ErrNoPuzzle.Error()
+	} else if len(result.Answers) > 0 {
+		answer.Answer = &result.Answers[0].Value
+		answer.Timings.SolveMS = milliseconds(result.Answers[0].Elapsed)
+	}
+	if result.Err != nil {
+		status = errorStatus(result.Err)
+		answer.Error = result.Err.Error()
+		var parseErr *utils.ParseError
+		if errors.As(result.Err, &parseErr) {
+			answer.Diagnostics = []Diagnostic{{parseErr.Name, parseErr.Line, parseErr.Text, parseErr.Err.Error()}}
+		}
+		logger.From(ctx).Info("failed", "year", year, "day", number, "part", part, "err", result.Err)
+	}
+	writeJSON(w, status, answer)
+}
+
+// errorStatus blames the input for whatever did not come from the solver
+// crashing or running out of time.
+func errorStatus(err error) int {
+	var timeout *runner.TimeoutError
+	var panicked *runner.PanicError
+	switch {
+	case errors.As(err, &timeout):
+		return http.StatusGatewayTimeout
+	case errors.As(err, &panicked):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
+
+func optionNames(day registry.Day) (names []string) {
+	for _, option := range day.Options {
+		names = append(names, option.Name)
+	}
+	return
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Options struct {
+	Scale *int `opt:"scale" usage:"multiply the sum"`
+}
+
+type numbers struct {
+	sum   int
+	scale int
+}
+
+func (n numbers) Part1(ctx context.Context) (int, error) { return n.sum * n.scale, nil }
+
+func (n numbers) Part2(ctx context.Context) (int, error) {
+	<-ctx.Done()
+	return 0, ctx.Err()
+}
+
+// stubborn ignores the context and only stops once released.
+type stubborn chan struct{}
+
+func (s stubborn) Part1(ctx context.Context) (int, error) {
+	<-s
+	return 1, nil
+}
+
+func (s stubborn) Part2(ctx context.Context) (int, error) { return s.Part1(ctx) }
+
+var release = make(stubborn)
+
+// last has a single puzzle, like the last day of a year.
+type last struct{}
+
+func (last) Part1(ctx context.Context) (int, error) { return 1, nil }
+func (last) Part2(ctx context.Context) (int, error) { return 0, registry.ErrNoPuzzle }
+
+func init() {
+	registry.RegisterWithOptions(2000, 201, func(r io.Reader, opts Options) (registry.Solver, error) {
+		sum, err := utils.ProcessReader(r, 0, strconv.Atoi, utils.Sum)
+		return numbers{sum, utils.Or(opts.Scale, 1)}, err
+	})
+	registry.Register(2000, 202, func(r io.Reader) (registry.Solver, error) {
+		return release, nil
+	})
+	registry.Register(2000, 203, func(r io.Reader) (registry.Solver, error) {
+		return last{}, nil
+	})
+}
+
+func post(t *testing.T, url string, body string) (int, Answer) {
+	t.Helper()
+	return postTo(t, (&Server{MaxInput: 16, Timeout: 50 * time.Millisecond}).Handler(), url, body)
+}
+
+func postTo(t *testing.T, handler http.Handler, url string, body string) (int, Answer) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, url, strings.NewReader(body)))
+	var answer Answer
+	if err := json.NewDecoder(recorder.Body).Decode(&answer); err != nil {
+		t.Fatal(err)
+	}
+	return recorder.Code, answer
+}
+
+func TestSolve(t *testing.T) {
+	code, answer := post(t, "/years/2000/days/201/parts/1?scale=2", "1\n2\n")
+	if code != http.StatusOK || answer.Answer == nil || *answer.Answer != 6 {
+		t.Errorf("got %d %+v", code, answer)
+	}
+	code, answer = post(t, "/years/2000/days/201/parts/1", "1\nx\n")
+	if code != http.StatusUnprocessableEntity || len(answer.Diagnostics) != 1 || answer.Diagnostics[0].Line != 2 {
+		t.Errorf("bad input: got %d %+v", code, answer)
+	}
+	code, answer = post(t, "/years/2000/days/201/parts/2", "1\n")
+	if code != http.StatusGatewayTimeout || answer.Answer != nil {
+		t.Errorf("slow part: got %d %+v", code, answer)
+	}
+	code, answer = post(t, "/years/2000/days/203/parts/2", "1\n")
+	if code != http.StatusNotFound || answer.Answer != nil || answer.Error == "" {
+		t.Errorf("no puzzle: got %d %+v", code, answer)
+	}
+	for url, want := range map[string]int{
+		"/years/2000/days/201/parts/1?size=2": http.StatusBadRequest,
+		"/years/2000/days/200/parts/1":        http.StatusNotFound,
+		"/days/201/parts/1":                   http.StatusNotFound,
+		"/years/2000/days/201/parts/3":        http.StatusNotFound,
+	} {
+		if code, answer := post(t, url, "1\n"); code != want {
+			t.Errorf("%s: got %d %+v, want %d", url, code, answer, want)
+		}
+	}
+	if code, answer := post(t, "/years/2000/days/201/parts/1", strings.Repeat("1\n", 10)); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large input: got %d %+v", code, answer)
+	}
+}
+
+func TestMaxSolves(t *testing.T) {
+	server := &Server{MaxInput: 16, Timeout: 50 * time.Millisecond, MaxSolves: 1}
+	handler := server.Handler()
+	if code, answer := postTo(t, handler, "/years/2000/days/202/parts/1", ""); code != http.StatusGatewayTimeout {
+		t.Errorf("stubborn day: got %d %+v", code, answer)
+	}
+	if code, answer := postTo(t, handler, "/years/2000/days/201/parts/1", "1\n"); code != http.StatusServiceUnavailable {
+		t.Errorf("while the stubborn day runs: got %d %+v", code, answer)
+	}
+	release <- struct{}{}
+	for start := time.Now(); len(server.slots) > 0; time.Sleep(time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatal("the stubborn day never gave its slot back")
+		}
+	}
+	if code, answer := postTo(t, handler, "/years/2000/days/201/parts/1", "1\n"); code != http.StatusOK {
+		t.Errorf("after the stubborn day stopped: got %d %+v", code, answer)
+	}
+}
+
+func TestMaxSolvesTimeout(t *testing.T) {
+	server := &Server{MaxInput: 16, Timeout: 50 * time.Millisecond, MaxSolves: 1}
+	handler := server.Handler()
+	if code, answer := postTo(t, handler, "/years/2000/days/201/parts/2", "1\n"); code != http.StatusGatewayTimeout {
+		t.Errorf("blocking part: got %d %+v", code, answer)
+	}
+	for start := time.Now(); len(server.slots) > 0; time.Sleep(time.Millisecond) {
+		if time.Since(start) > time.Second {
+			t.Fatal("the timed out solver never gave its slot back")
+		}
+	}
+	if code, answer := postTo(t, handler, "/years/2000/days/201/parts/1", "1\n"); code != http.StatusOK {
+		t.Errorf("after the timeout: got %d %+v", code, answer)
+	}
+}
+
+func TestListDays(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	(&Server{}).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/days", nil))
+	var days []Day
+	if err := json.NewDecoder(recorder.Body).Decode(&days); err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 3 || days[0].Year != 2000 || days[0].Day != 201 || days[0].Options[0].Name != "scale" {
+		t.Errorf("got %+v", days)
+	}
+}
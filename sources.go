@@ -0,0 +1,41 @@
+package main
+
+import (
+	"advent/registry"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// sources are hashed into the solver version, so cached answers only
+// survive as long as the code that computed them.
+//
+//go:embed y*/day*/*.go registry/*.go utils/*.go utils/*/*.go
+var sources embed.FS
+
+// solverVersion hashes the sources of the day together with the shared
+// packages every day builds on.
+func solverVersion(day registry.Day) (string, error) {
+	hash := sha256.New()
+	for _, pattern := range []string{fmt.Sprintf("y%d/day%02d/*.go", day.Year, day.Number), "registry/*.go", "utils/*.go", "utils/*/*.go"} {
+		names, err := fs.Glob(sources, pattern)
+		if err != nil {
+			return "", err
+		}
+		for _, name := range names {
+			if strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			data, err := sources.ReadFile(name)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(hash, "%s\x00%d\x00", name, len(data))
+			hash.Write(data)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
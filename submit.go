@@ -0,0 +1,80 @@
+package main
+
+import (
+	"advent/aoc"
+	"advent/registry"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+const attemptLog = "input/attempts.jsonl"
+
+func submitCommand(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	dayNum := flags.Int("day", 0, "day to submit")
+	part := flags.Int("part", 0, "part to submit")
+	year := flags.Int("year", registry.DefaultYear, "puzzle year")
+	input := flags.String("input", "", "input file (default: input/YEAR/dayNN.txt)")
+	logPath := flags.String("log", attemptLog, "file with previous attempts")
+	flags.Parse(args)
+
+	day, found := registry.Get(*year, *dayNum)
+	if !found {
+		return fmt.Errorf("%d day %d is not registered", *year, *dayNum)
+	}
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("there is no part %d", *part)
+	}
+	if *input == "" {
+		*input = day.DefaultInput()
+	}
+	ctx := context.Background()
+	solver, err := day.ParseFile(*input, nil)
+	if err != nil {
+		return err
+	}
+	answer, err := day.Solve(ctx, solver, *part)
+	if errors.Is(err, registry.ErrNoPuzzle) {
+		return fmt.Errorf("not submitting: %v part %d is not applicable", day, *part)
+	}
+	if err != nil {
+		return err
+	}
+
+	log, err := aoc.OpenLog(*logPath)
+	if err != nil {
+		return err
+	}
+	if err := log.Check(*year, day.Number, *part, answer); err != nil {
+		return fmt.Errorf("not submitting: %w", err)
+	}
+	client, err := aoc.DefaultClient()
+	if err != nil {
+		return err
+	}
+	outcome, err := client.Submit(ctx, *year, day.Number, *part, answer)
+	if err != nil {
+		return err
+	}
+	switch outcome.Verdict {
+	case aoc.Unknown:
+		return fmt.Errorf("unexpected response: %s", outcome.Message)
+	case aoc.Wait:
+		return fmt.Errorf("submitted too recently, wait %v", outcome.Wait)
+	}
+	fmt.Printf("%v part %d: %d is %v\n", day, *part, answer, outcome.Verdict)
+	if outcome.Wait > 0 {
+		fmt.Printf("wait %v before the next attempt\n", outcome.Wait)
+	}
+	return log.Record(aoc.Attempt{
+		Year:    *year,
+		Day:     day.Number,
+		Part:    *part,
+		Answer:  answer,
+		Verdict: outcome.Verdict,
+		Time:    time.Now(),
+	})
+}
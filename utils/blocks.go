@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Block is a run of non-blank lines, starting at line number Line.
+type Block struct {
+	Name  string
+	Line  int
+	Lines []string
+}
+
+// Err locates err at the i-th line of the block.
+func (block Block) Err(i int, err error) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return err
+	}
+	text := ""
+	if i < len(block.Lines) {
+		text = block.Lines[i]
+	}
+	return &ParseError{block.Name, block.Line + i, text, err}
+}
+
+// ReadBlocks splits the input into blocks separated by blank lines.
+func ReadBlocks(r io.Reader) ([]Block, error) {
+	stream := NewStream(r)
+	blocks := Collect(stream.Blocks())
+	return blocks, stream.Err()
+}
+
+// Section parses one block of the input into the result.
+type Section[R any] struct {
+	parse  func(R, Block) (R, error)
+	repeat bool
+}
+
+// Repeated makes the section take all the remaining blocks.
+func (section Section[R]) Repeated() Section[R] {
+	section.repeat = true
+	return section
+}
+
+// BlockSection is for blocks that need to be parsed as a whole. Errors that
+// are not located yet are reported at the first line of the block.
+func BlockSection[R any](parse func(R, Block) (R, error)) Section[R] {
+	return Section[R]{parse: func(result R, block Block) (R, error) {
+		result, err := parse(result, block)
+		if err != nil {
+			return result, block.Err(0, err)
+		}
+		return result, nil
+	}}
+}
+
+// LineSection is a block of a single line.
+func LineSection[R any, T any](parseLine func(string) (T, error), join func(R, T) R) Section[R] {
+	return Section[R]{parse: func(result R, block Block) (R, error) {
+		if len(block.Lines) != 1 {
+			return result, block.Err(1, fmt.Errorf("expected a single line, found %d", len(block.Lines)))
+		}
+		parsed, err := parseLine(block.Lines[0])
+		if err != nil {
+			return result, block.Err(0, err)
+		}
+		return join(result, parsed), nil
+	}}
+}
+
+// LinesSection parses every line of the block on its own.
+func LinesSection[R any, T any](parseLine func(string) (T, error), join func(R, T) R) Section[R] {
+	return Section[R]{parse: func(result R, block Block) (R, error) {
+		for i, line := range block.Lines {
+			parsed, err := parseLine(line)
+			if err != nil {
+				return result, block.Err(i, err)
+			}
+			result = join(result, parsed)
+		}
+		return result, nil
+	}}
+}
+
+// HeaderSection is a header line followed by lines parsed on their own.
+func HeaderSection[R any, H any, T any](parseHeader func(string) (H, error), parseLine func(string) (T, error), join func(R, H, []T) R) Section[R] {
+	return Section[R]{parse: func(result R, block Block) (R, error) {
+		header, err := parseHeader(block.Lines[0])
+		if err != nil {
+			return result, block.Err(0, err)
+		}
+		lines := make([]T, len(block.Lines)-1)
+		for i, line := range block.Lines[1:] {
+			if lines[i], err = parseLine(line); err != nil {
+				return result, block.Err(i+1, err)
+			}
+		}
+		return join(result, header, lines), nil
+	}}
+}
+
+// KeyValueSection is lines of keys and values split by sep, as in "seeds: 1 2".
+func KeyValueSection[R any](sep string, set func(result R, key, value string) (R, error)) Section[R] {
+	return Section[R]{parse: func(result R, block Block) (R, error) {
+		for i, line := range block.Lines {
+			key, value, found := strings.Cut(line, sep)
+			if !found {
+				return result, block.Err(i, fmt.Errorf("expected key%svalue", sep))
+			}
+			var err error
+			if result, err = set(result, key, value); err != nil {
+				return result, block.Err(i, err)
+			}
+		}
+		return result, nil
+	}}
+}
+
+// ProcessBlocks parses the blocks of r with one section each, in order.
+func ProcessBlocks[R any](r io.Reader, seed R, sections ...Section[R]) (R, error) {
+	result := seed
+	blocks, err := ReadBlocks(r)
+	if err != nil {
+		return result, err
+	}
+	i := 0
+	for n, section := range sections {
+		if i == len(blocks) {
+			line := 1
+			if len(blocks) > 0 {
+				last := blocks[len(blocks)-1]
+				line = last.Line + len(last.Lines)
+			}
+			return result, &ParseError{Name: InputName(r), Line: line, Err: fmt.Errorf("section %d of %d is missing", n+1, len(sections))}
+		}
+		for ok := true; ok; ok = section.repeat && i < len(blocks) {
+			if result, err = section.parse(result, blocks[i]); err != nil {
+				return result, err
+			}
+			i++
+		}
+	}
+	if i < len(blocks) {
+		return result, blocks[i].Err(0, errors.New("unexpected section"))
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type sample struct {
+	name   string
+	header string
+	values []int
+}
+
+func sections() []Section[sample] {
+	setName := func(s sample, key, value string) (sample, error) {
+		if key != "name" {
+			return s, errors.New("expected name")
+		}
+		s.name = value
+		return s, nil
+	}
+	join := func(s sample, header string, values []int) sample {
+		s.header = header
+		s.values = append(s.values, values...)
+		return s
+	}
+	return []Section[sample]{
+		KeyValueSection(": ", setName),
+		HeaderSection(Lift(strings.ToUpper), strconv.Atoi, join).Repeated(),
+	}
+}
+
+func process(input string) (sample, error) {
+	return ProcessBlocks(Named("sample", strings.NewReader(input)), sample{}, sections()...)
+}
+
+func TestReadBlocks(t *testing.T) {
+	blocks, err := ReadBlocks(strings.NewReader("\na\nb\n\n\nc\n \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 2 || blocks[0].Line != 2 || len(blocks[0].Lines) != 2 || blocks[1].Line != 6 {
+		t.Errorf("got %+v", blocks)
+	}
+}
+
+func TestProcessBlocks(t *testing.T) {
+	s, err := process("name: x\n\nfirst\n1\n2\n\nsecond\n3\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.name != "x" || s.header != "SECOND" || len(s.values) != 3 {
+		t.Errorf("got %+v", s)
+	}
+}
+
+func TestProcessBlocksErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"name: x\n\nfirst\n1\nx\n": `sample:5: strconv.Atoi: parsing "x": invalid syntax (in "x")`,
+		"name: x\n":                "sample:2: section 2 of 2 is missing",
+		"":                         "sample:1: section 1 of 2 is missing",
+		"size: 2\n":                `sample:1: expected name (in "size: 2")`,
+		"name x\n\nfirst\n":        `sample:1: expected key: value (in "name x")`,
+	} {
+		_, err := process(input)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || err.Error() != want {
+			t.Errorf("%q: got %v, want %s", input, err, want)
+		}
+	}
+}
+
+func TestLineSection(t *testing.T) {
+	join := func(n, line int) int { return n + line }
+	_, err := ProcessBlocks(strings.NewReader("1\n2\n"), 0, LineSection(strconv.Atoi, join))
+	if err == nil || !strings.Contains(err.Error(), ":2: expected a single line, found 2") {
+		t.Errorf("got %v", err)
+	}
+	_, err = ProcessBlocks(strings.NewReader("1\n\n2\n"), 0, LineSection(strconv.Atoi, join))
+	if err == nil || !strings.Contains(err.Error(), ":3: unexpected section") {
+		t.Errorf("got %v", err)
+	}
+}
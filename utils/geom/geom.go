@@ -0,0 +1,99 @@
+package geom
+
+import (
+	"advent/utils"
+	"fmt"
+)
+
+// Point is a position on a grid, with I growing down and J growing right.
+type Point struct {
+	I, J int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.I + q.I, p.J + q.J}
+}
+
+func (p Point) Move(dir Dir, n int) Point {
+	delta := dir.Delta()
+	return Point{p.I + n*delta.I, p.J + n*delta.J}
+}
+
+func (p Point) Step(dir Dir) Point {
+	return p.Move(dir, 1)
+}
+
+func (p Point) Manhattan(q Point) int {
+	return utils.Abs(p.I-q.I) + utils.Abs(p.J-q.J)
+}
+
+// Dir values go clockwise, so the digit encoding of directions is Dir(digit).
+type Dir byte
+
+const (
+	Right Dir = iota
+	Down
+	Left
+	Up
+)
+
+var Dirs = [4]Dir{Right, Down, Left, Up}
+
+func (dir Dir) Delta() Point {
+	switch dir {
+	case Right:
+		return Point{0, 1}
+	case Down:
+		return Point{1, 0}
+	case Left:
+		return Point{0, -1}
+	case Up:
+		return Point{-1, 0}
+	}
+	panic(fmt.Sprintf("unknown direction %d", dir))
+}
+
+// Turn rotates the direction clockwise n times, counterclockwise for negative n.
+func (dir Dir) Turn(n int) Dir {
+	return Dir(((int(dir)+n)%4 + 4) % 4)
+}
+
+func (dir Dir) TurnRight() Dir {
+	return dir.Turn(1)
+}
+
+func (dir Dir) TurnLeft() Dir {
+	return dir.Turn(-1)
+}
+
+func (dir Dir) Reverse() Dir {
+	return dir.Turn(2)
+}
+
+func (dir Dir) Vertical() bool {
+	return dir == Up || dir == Down
+}
+
+func (dir Dir) Arrow() rune {
+	return []rune("→↓←↑")[dir]
+}
+
+func (dir Dir) String() string {
+	return string("RDLU"[dir])
+}
+
+// ParseDir accepts letters (URDL), arrows (^>v<) and digits (0 for right,
+// then clockwise).
+func ParseDir(r rune) (Dir, error) {
+	switch r {
+	case 'R', '>', '0':
+		return Right, nil
+	case 'D', 'v', '1':
+		return Down, nil
+	case 'L', '<', '2':
+		return Left, nil
+	case 'U', '^', '3':
+		return Up, nil
+	}
+	return 0, fmt.Errorf("unknown direction %q", r)
+}
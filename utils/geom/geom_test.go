@@ -0,0 +1,43 @@
+package geom
+
+import "testing"
+
+func TestDir(t *testing.T) {
+	for _, dir := range Dirs {
+		if dir.TurnRight().TurnLeft() != dir || dir.Reverse().Reverse() != dir {
+			t.Errorf("%v: turns do not cancel out", dir)
+		}
+		if dir.Turn(-3) != dir.TurnRight() || dir.Turn(6) != dir.Reverse() {
+			t.Errorf("%v: turns are not periodic", dir)
+		}
+		if back := (Point{}).Step(dir).Step(dir.Reverse()); back != (Point{}) {
+			t.Errorf("%v: reverse step ends at %v", dir, back)
+		}
+	}
+	if Up.TurnRight() != Right || Right.TurnRight() != Down || Left.TurnLeft() != Down {
+		t.Error("turns are not clockwise")
+	}
+}
+
+func TestParseDir(t *testing.T) {
+	for _, encoding := range []string{"RDLU", ">v<^", "0123"} {
+		for i, r := range encoding {
+			if dir, err := ParseDir(r); err != nil || dir != Dirs[i] {
+				t.Errorf("%q: got %v, %v", r, dir, err)
+			}
+		}
+	}
+	if _, err := ParseDir('x'); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestPoint(t *testing.T) {
+	p := Point{2, 3}
+	if got := p.Move(Up, 5); got != (Point{-3, 3}) {
+		t.Errorf("got %v", got)
+	}
+	if got := p.Move(Left, 4).Manhattan(p.Move(Down, 2)); got != 6 {
+		t.Errorf("got distance %d", got)
+	}
+}
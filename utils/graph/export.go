@@ -0,0 +1,171 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func dotAttrs(attrs Attrs) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	var pairs []string
+	for _, key := range sortedKeys(attrs) {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, strconv.Quote(attrs[key])))
+	}
+	return " [" + strings.Join(pairs, ", ") + "]"
+}
+
+func WriteDOT(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	kind, arrow := "graph", "--"
+	if g.Directed {
+		kind, arrow = "digraph", "->"
+	}
+	fmt.Fprintf(bw, "%s %s {\n", kind, strconv.Quote(g.Name))
+	for _, node := range g.Nodes {
+		fmt.Fprintf(bw, "  %s%s;\n", strconv.Quote(node.ID), dotAttrs(node.Attrs))
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(bw, "  %s %s %s%s;\n", strconv.Quote(edge.From), arrow, strconv.Quote(edge.To), dotAttrs(edge.Attrs))
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+var mermaidShapes = map[string][2]string{
+	"box":     {"[", "]"},
+	"ellipse": {"(", ")"},
+	"circle":  {"((", "))"},
+	"diamond": {"{", "}"},
+}
+
+func mermaidText(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// WriteMermaid writes a flowchart. Node ids are used as they are, so they
+// have to be valid Mermaid ids.
+func WriteMermaid(w io.Writer, g *Graph) error {
+	bw := bufio.NewWriter(w)
+	arrow := "---"
+	if g.Directed {
+		arrow = "-->"
+	}
+	bw.WriteString("flowchart TD\n")
+	for _, node := range g.Nodes {
+		label, found := node.Attrs["label"]
+		if !found {
+			label = node.ID
+		}
+		shape, found := mermaidShapes[node.Attrs["shape"]]
+		if !found {
+			shape = mermaidShapes["box"]
+		}
+		fmt.Fprintf(bw, "  %s%s%s%s\n", node.ID, shape[0], mermaidText(label), shape[1])
+		if color, found := node.Attrs["color"]; found {
+			fmt.Fprintf(bw, "  style %s fill:%s\n", node.ID, color)
+		}
+	}
+	for idx, edge := range g.Edges {
+		link := arrow
+		if label, found := edge.Attrs["label"]; found {
+			link += "|" + mermaidText(label) + "|"
+		}
+		fmt.Fprintf(bw, "  %s %s %s\n", edge.From, link, edge.To)
+		if color, found := edge.Attrs["color"]; found {
+			fmt.Fprintf(bw, "  linkStyle %d stroke:%s\n", idx, color)
+		}
+	}
+	return bw.Flush()
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphML struct {
+	XMLName xml.Name     `xml:"http://graphml.graphdrawing.org/xmlns graphml"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		ID          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+// graphMLKeys declares a key for every attribute name used by nodes or edges.
+type graphMLKeys struct {
+	doc *graphML
+	ids map[string]string
+}
+
+func (keys graphMLKeys) data(kind string, attrs Attrs) (data []graphMLData) {
+	for _, name := range sortedKeys(attrs) {
+		id, found := keys.ids[kind+"/"+name]
+		if !found {
+			id = fmt.Sprintf("d%d", len(keys.ids))
+			keys.ids[kind+"/"+name] = id
+			keys.doc.Keys = append(keys.doc.Keys, graphMLKey{id, kind, name, "string"})
+		}
+		data = append(data, graphMLData{id, attrs[name]})
+	}
+	return
+}
+
+func WriteGraphML(w io.Writer, g *Graph) error {
+	doc := &graphML{}
+	doc.Graph.ID = g.Name
+	doc.Graph.EdgeDefault = "undirected"
+	if g.Directed {
+		doc.Graph.EdgeDefault = "directed"
+	}
+	keys := graphMLKeys{doc, make(map[string]string)}
+	for _, node := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{node.ID, keys.data("node", node.Attrs)})
+	}
+	for _, edge := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{edge.From, edge.To, keys.data("edge", edge.Attrs)})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func WriteJSON(w io.Writer, g *Graph) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Attrs are free-form attributes. Exporters pass them through, and the
+// common "label", "color" and "shape" keys are understood by all of them.
+type Attrs map[string]string
+
+type Node struct {
+	ID    string `json:"id"`
+	Attrs Attrs  `json:"attrs,omitempty"`
+}
+
+type Edge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Attrs Attrs  `json:"attrs,omitempty"`
+}
+
+type Graph struct {
+	Name     string `json:"name"`
+	Directed bool   `json:"directed"`
+	Nodes    []Node `json:"nodes"`
+	Edges    []Edge `json:"edges"`
+	index    map[string]int
+}
+
+func New(name string, directed bool) *Graph {
+	return &Graph{Name: name, Directed: directed, index: make(map[string]int)}
+}
+
+// AddNode adds the node or merges attrs into the existing one.
+func (g *Graph) AddNode(id string, attrs Attrs) {
+	idx, found := g.index[id]
+	if !found {
+		idx = len(g.Nodes)
+		g.index[id] = idx
+		g.Nodes = append(g.Nodes, Node{ID: id})
+	}
+	for key, value := range attrs {
+		if g.Nodes[idx].Attrs == nil {
+			g.Nodes[idx].Attrs = make(Attrs)
+		}
+		g.Nodes[idx].Attrs[key] = value
+	}
+}
+
+// AddEdge adds the edge along with its nodes if they are missing.
+func (g *Graph) AddEdge(from, to string, attrs Attrs) {
+	g.AddNode(from, nil)
+	g.AddNode(to, nil)
+	g.Edges = append(g.Edges, Edge{from, to, attrs})
+}
+
+// Sort orders nodes and edges by id, for days that build graphs from maps.
+func (g *Graph) Sort() {
+	slices.SortFunc(g.Nodes, func(a, b Node) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	for idx, node := range g.Nodes {
+		g.index[node.ID] = idx
+	}
+	slices.SortStableFunc(g.Edges, func(a, b Edge) int {
+		if a.From != b.From {
+			return cmp.Compare(a.From, b.From)
+		}
+		return cmp.Compare(a.To, b.To)
+	})
+}
+
+// Exportable is implemented by solvers whose input is a graph.
+type Exportable interface {
+	ExportGraph() *Graph
+}
+
+type Writer func(w io.Writer, g *Graph) error
+
+var Formats = map[string]Writer{
+	"dot":     WriteDOT,
+	"mermaid": WriteMermaid,
+	"graphml": WriteGraphML,
+	"json":    WriteJSON,
+}
+
+// ParseTarget splits a format:path target, such as dot:day08.dot.
+func ParseTarget(target string) (Writer, string, error) {
+	format, path, found := strings.Cut(target, ":")
+	if !found || path == "" {
+		return nil, "", fmt.Errorf("graph target %q is not format:path", target)
+	}
+	write, found := Formats[format]
+	if !found {
+		return nil, "", fmt.Errorf("unknown graph format %q (known: %s)", format, formatNames())
+	}
+	return write, path, nil
+}
+
+func formatNames() string {
+	names := make([]string, 0, len(Formats))
+	for name := range Formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func WriteFile(write Writer, path string, g *Graph) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file, g); err != nil {
+		file.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Close()
+}
+
+func sortedKeys(attrs Attrs) []string {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
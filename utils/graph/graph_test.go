@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func sample() *Graph {
+	g := New("sample", true)
+	g.AddNode("b", Attrs{"color": "red"})
+	g.AddEdge("b", "a", Attrs{"label": "x"})
+	g.AddEdge("a", "c", nil)
+	g.AddNode("b", Attrs{"shape": "diamond"})
+	return g
+}
+
+func export(t *testing.T, write Writer, g *Graph) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := write(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestAddNodeMerges(t *testing.T) {
+	g := sample()
+	if len(g.Nodes) != 3 {
+		t.Fatalf("got nodes %v", g.Nodes)
+	}
+	if attrs := g.Nodes[0].Attrs; attrs["color"] != "red" || attrs["shape"] != "diamond" {
+		t.Errorf("got attrs %v", attrs)
+	}
+}
+
+func TestSort(t *testing.T) {
+	g := sample()
+	g.Sort()
+	if g.Nodes[0].ID != "a" || g.Edges[0].From != "a" {
+		t.Errorf("got %v %v", g.Nodes, g.Edges)
+	}
+	g.AddNode("a", Attrs{"label": "A"})
+	if g.Nodes[0].Attrs["label"] != "A" {
+		t.Errorf("index is stale after sorting")
+	}
+}
+
+func TestDOT(t *testing.T) {
+	want := `digraph "sample" {
+  "b" [color="red", shape="diamond"];
+  "a";
+  "c";
+  "b" -> "a" [label="x"];
+  "a" -> "c";
+}
+`
+	if got := export(t, WriteDOT, sample()); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	undirected := New("u", false)
+	undirected.AddEdge("a", "b", nil)
+	if got := export(t, WriteDOT, undirected); !strings.Contains(got, `"a" -- "b"`) {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestMermaid(t *testing.T) {
+	want := `flowchart TD
+  b{"b"}
+  style b fill:red
+  a["a"]
+  c["c"]
+  b -->|"x"| a
+  a --> c
+`
+	if got := export(t, WriteMermaid, sample()); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGraphML(t *testing.T) {
+	out := export(t, WriteGraphML, sample())
+	var doc graphML
+	if err := xml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Keys) != 3 || len(doc.Graph.Nodes) != 3 || len(doc.Graph.Edges) != 2 {
+		t.Errorf("got %+v", doc)
+	}
+	if doc.Graph.EdgeDefault != "directed" {
+		t.Errorf("got edge default %q", doc.Graph.EdgeDefault)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var g Graph
+	if err := json.Unmarshal([]byte(export(t, WriteJSON, sample())), &g); err != nil {
+		t.Fatal(err)
+	}
+	if g.Name != "sample" || !g.Directed || len(g.Nodes) != 3 || g.Edges[0].Attrs["label"] != "x" {
+		t.Errorf("got %+v", g)
+	}
+}
+
+func TestParseTarget(t *testing.T) {
+	if _, path, err := ParseTarget("mermaid:out/g.mmd"); err != nil || path != "out/g.mmd" {
+		t.Errorf("got %q, %v", path, err)
+	}
+	for _, target := range []string{"dot", "dot:", "svg:g.svg"} {
+		if _, _, err := ParseTarget(target); err == nil {
+			t.Errorf("%q is accepted", target)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package grid
+
+import (
+	"advent/utils"
+	"advent/utils/geom"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// Grid is a rectangular grid of cells, indexed by row and then column.
+type Grid[T any] [][]T
+
+func New[T any](h, w int, fill T) Grid[T] {
+	grid := make(Grid[T], h)
+	for i := range grid {
+		grid[i] = make([]T, w)
+		for j := range grid[i] {
+			grid[i][j] = fill
+		}
+	}
+	return grid
+}
+
+// ParseRow maps every rune of the line to a cell.
+func ParseRow[T any](line string, cell func(rune) (T, error)) ([]T, error) {
+	row := make([]T, 0, len(line))
+	for j, r := range []rune(line) {
+		value, err := cell(r)
+		if err != nil {
+			return nil, fmt.Errorf("column %d: %w", j+1, err)
+		}
+		row = append(row, value)
+	}
+	return row, nil
+}
+
+// Parse reads one row per line and checks that the grid is rectangular.
+func Parse[T any](r io.Reader, cell func(rune) (T, error)) (Grid[T], error) {
+	parseRow := func(line string) ([]T, error) {
+		return ParseRow(line, cell)
+	}
+	appendRow := func(grid Grid[T], row []T) Grid[T] {
+		return append(grid, row)
+	}
+	grid, err := utils.ProcessReader(r, Grid[T]{}, parseRow, appendRow)
+	if err == nil {
+		err = utils.CheckGrid(r, grid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return grid, nil
+}
+
+// Section reads a block of the input as a rectangular grid.
+func Section[R any, T any](cell func(rune) (T, error), join func(R, Grid[T]) R) utils.Section[R] {
+	return utils.BlockSection(func(result R, block utils.Block) (R, error) {
+		grid := make(Grid[T], len(block.Lines))
+		for i, line := range block.Lines {
+			row, err := ParseRow(line, cell)
+			if err == nil && i > 0 && len(row) != len(grid[0]) {
+				err = fmt.Errorf("row is %d wide, expected %d", len(row), len(grid[0]))
+			}
+			if err != nil {
+				return result, block.Err(i, err)
+			}
+			grid[i] = row
+		}
+		return join(result, grid), nil
+	})
+}
+
+func (grid Grid[T]) Height() int {
+	return len(grid)
+}
+
+func (grid Grid[T]) Width() int {
+	if len(grid) == 0 {
+		return 0
+	}
+	return len(grid[0])
+}
+
+func (grid Grid[T]) InBounds(p geom.Point) bool {
+	return p.I >= 0 && p.I < grid.Height() && p.J >= 0 && p.J < grid.Width()
+}
+
+func (grid Grid[T]) At(p geom.Point) T {
+	return grid[p.I][p.J]
+}
+
+// Get is At for positions that may lie outside the grid.
+func (grid Grid[T]) Get(p geom.Point) (value T, found bool) {
+	if !grid.InBounds(p) {
+		return
+	}
+	return grid[p.I][p.J], true
+}
+
+func (grid Grid[T]) Set(p geom.Point, value T) {
+	grid[p.I][p.J] = value
+}
+
+var (
+	offsets4 = []geom.Point{geom.Up.Delta(), geom.Left.Delta(), geom.Right.Delta(), geom.Down.Delta()}
+	offsets8 = []geom.Point{
+		geom.Up.Delta().Add(geom.Left.Delta()), geom.Up.Delta(), geom.Up.Delta().Add(geom.Right.Delta()),
+		geom.Left.Delta(), geom.Right.Delta(),
+		geom.Down.Delta().Add(geom.Left.Delta()), geom.Down.Delta(), geom.Down.Delta().Add(geom.Right.Delta()),
+	}
+)
+
+func (grid Grid[T]) neighbors(p geom.Point, offsets []geom.Point) iter.Seq[geom.Point] {
+	return func(yield func(geom.Point) bool) {
+		for _, offset := range offsets {
+			next := p.Add(offset)
+			if grid.InBounds(next) && !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 yields the orthogonal neighbors of p inside the grid, top to bottom
+// and left to right.
+func (grid Grid[T]) Neighbors4(p geom.Point) iter.Seq[geom.Point] {
+	return grid.neighbors(p, offsets4)
+}
+
+// Neighbors8 is Neighbors4 with diagonal neighbors.
+func (grid Grid[T]) Neighbors8(p geom.Point) iter.Seq[geom.Point] {
+	return grid.neighbors(p, offsets8)
+}
+
+// Find returns the first matching cell in reading order.
+func (grid Grid[T]) Find(match func(T) bool) (geom.Point, bool) {
+	for i, row := range grid {
+		for j, cell := range row {
+			if match(cell) {
+				return geom.Point{I: i, J: j}, true
+			}
+		}
+	}
+	return geom.Point{}, false
+}
+
+// Map builds a grid of the same shape from every cell.
+func Map[T, R any](grid Grid[T], f func(T) R) Grid[R] {
+	result := make(Grid[R], len(grid))
+	for i, row := range grid {
+		result[i] = make([]R, len(row))
+		for j, cell := range row {
+			result[i][j] = f(cell)
+		}
+	}
+	return result
+}
+
+func (grid Grid[T]) Clone() Grid[T] {
+	return Map(grid, utils.Identity[T])
+}
+
+func (grid Grid[T]) reshape(h, w int, from func(i, j int) T) Grid[T] {
+	result := make(Grid[T], h)
+	for i := range result {
+		result[i] = make([]T, w)
+		for j := range result[i] {
+			result[i][j] = from(i, j)
+		}
+	}
+	return result
+}
+
+func (grid Grid[T]) Transpose() Grid[T] {
+	return grid.reshape(grid.Width(), grid.Height(), func(i, j int) T {
+		return grid[j][i]
+	})
+}
+
+func (grid Grid[T]) RotateClockwise() Grid[T] {
+	h := grid.Height()
+	return grid.reshape(grid.Width(), h, func(i, j int) T {
+		return grid[h-1-j][i]
+	})
+}
+
+func (grid Grid[T]) RotateCounterclockwise() Grid[T] {
+	w := grid.Width()
+	return grid.reshape(w, grid.Height(), func(i, j int) T {
+		return grid[j][w-1-i]
+	})
+}
+
+// FlipHorizontal mirrors the grid left to right.
+func (grid Grid[T]) FlipHorizontal() Grid[T] {
+	w := grid.Width()
+	return grid.reshape(grid.Height(), w, func(i, j int) T {
+		return grid[i][w-1-j]
+	})
+}
+
+// FlipVertical turns the grid upside down.
+func (grid Grid[T]) FlipVertical() Grid[T] {
+	h := grid.Height()
+	return grid.reshape(h, grid.Width(), func(i, j int) T {
+		return grid[h-1-i][j]
+	})
+}
+
+// Render joins the text of every cell, one row per line.
+func (grid Grid[T]) Render(cell func(geom.Point, T) string) string {
+	var sb strings.Builder
+	for i, row := range grid {
+		for j, value := range row {
+			sb.WriteString(cell(geom.Point{I: i, J: j}, value))
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+// Runes renders cells that are runes themselves.
+func Runes[T ~rune](_ geom.Point, cell T) string {
+	return string(rune(cell))
+}
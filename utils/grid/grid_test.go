@@ -0,0 +1,109 @@
+package grid
+
+import (
+	"advent/utils"
+	"advent/utils/geom"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type Tile rune
+
+func parseTile(r rune) (Tile, error) {
+	if r != '.' && r != '#' && r != 'S' {
+		return 0, errors.New("unknown tile")
+	}
+	return Tile(r), nil
+}
+
+func parse(t *testing.T, text string) Grid[Tile] {
+	t.Helper()
+	grid, err := Parse(strings.NewReader(text), parseTile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grid
+}
+
+func TestParse(t *testing.T) {
+	grid := parse(t, "#.S\n..#\n")
+	if grid.Height() != 2 || grid.Width() != 3 {
+		t.Errorf("got %dx%d grid", grid.Height(), grid.Width())
+	}
+	if start, found := grid.Find(func(tile Tile) bool { return tile == 'S' }); !found || start != (geom.Point{I: 0, J: 2}) {
+		t.Errorf("got start %v, %v", start, found)
+	}
+	if got := grid.Render(Runes[Tile]); got != "#.S\n..#\n" {
+		t.Errorf("rendered %q", got)
+	}
+	for _, text := range []string{"", "#.\n#\n", "#x\n"} {
+		if _, err := Parse(strings.NewReader(text), parseTile); err == nil {
+			t.Errorf("%q: expected an error", text)
+		}
+	}
+}
+
+func TestSection(t *testing.T) {
+	appendGrid := func(grids []Grid[Tile], grid Grid[Tile]) []Grid[Tile] {
+		return append(grids, grid)
+	}
+	section := Section(parseTile, appendGrid).Repeated()
+	grids, err := utils.ProcessBlocks(strings.NewReader("#.\n.#\n\nS\n"), nil, section)
+	if err != nil || len(grids) != 2 || grids[1].Width() != 1 {
+		t.Errorf("got %v, %v", grids, err)
+	}
+	_, err = utils.ProcessBlocks(strings.NewReader("#.\n\n#.\n.\n"), nil, section)
+	if err == nil || !strings.Contains(err.Error(), ":4: row is 1 wide, expected 2") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestAccess(t *testing.T) {
+	grid := parse(t, "#.\n..\n")
+	if _, found := grid.Get(geom.Point{I: 2, J: 0}); found {
+		t.Error("found a cell below the grid")
+	}
+	clone := grid.Clone()
+	clone.Set(geom.Point{I: 0, J: 0}, 'S')
+	if grid.At(geom.Point{I: 0, J: 0}) != '#' {
+		t.Error("clone shares cells with the grid")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	grid := New(3, 3, Tile('.'))
+	if got := slices.Collect(grid.Neighbors4(geom.Point{I: 0, J: 0})); !reflect.DeepEqual(got, []geom.Point{{I: 0, J: 1}, {I: 1, J: 0}}) {
+		t.Errorf("corner has neighbors %v", got)
+	}
+	if got := slices.Collect(grid.Neighbors4(geom.Point{I: 1, J: 1})); len(got) != 4 {
+		t.Errorf("center has neighbors %v", got)
+	}
+	if got := slices.Collect(grid.Neighbors8(geom.Point{I: 1, J: 1})); len(got) != 8 {
+		t.Errorf("center has neighbors %v", got)
+	}
+	if got := slices.Collect(grid.Neighbors8(geom.Point{I: 2, J: 1})); len(got) != 5 {
+		t.Errorf("edge has neighbors %v", got)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	grid := parse(t, "#..\n.S.\n")
+	for _, c := range []struct {
+		name string
+		got  Grid[Tile]
+		want string
+	}{
+		{"transpose", grid.Transpose(), "#.\n.S\n..\n"},
+		{"clockwise", grid.RotateClockwise(), ".#\nS.\n..\n"},
+		{"counterclockwise", grid.RotateCounterclockwise(), "..\n.S\n#.\n"},
+		{"horizontal", grid.FlipHorizontal(), "..#\n.S.\n"},
+		{"vertical", grid.FlipVertical(), ".S.\n#..\n"},
+	} {
+		if got := c.got.Render(Runes[Tile]); got != c.want {
+			t.Errorf("%s: got %q, want %q", c.name, got, c.want)
+		}
+	}
+}
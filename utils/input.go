@@ -2,41 +2,109 @@ package utils
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 )
 
-func ProcessInput[T any, R any](name string, seed R, parseLine func(string) T, join func(R, T) R) R {
-	parseLineIgnoringNumbers := func(line string, _ int) T {
-		return parseLine(line)
+type ParseError struct {
+	Name string
+	Line int
+	Text string
+	Err  error
+}
+
+func (err *ParseError) Error() string {
+	if err.Text == "" {
+		return fmt.Sprintf("%s:%d: %v", err.Name, err.Line, err.Err)
 	}
-	return ProcessInputWithLineNumbers(name, seed, parseLineIgnoringNumbers, join)
+	return fmt.Sprintf("%s:%d: %v (in %q)", err.Name, err.Line, err.Err, err.Text)
+}
+
+func (err *ParseError) Unwrap() error {
+	return err.Err
+}
+
+type namedReader struct {
+	io.Reader
+	name string
 }
 
-func ProcessInputWithLineNumbers[T any, R any](name string, seed R, parseLine func(string, int) T, join func(R, T) R) R {
-	file, err := os.Open("input/" + name)
-	if err != nil {
-		log.Fatal(err)
+func (reader namedReader) Name() string {
+	return reader.name
+}
+
+// Named attaches a name to r that shows up in parse errors.
+func Named(name string, r io.Reader) io.Reader {
+	return namedReader{r, name}
+}
+
+func InputName(r io.Reader) string {
+	if named, ok := r.(interface{ Name() string }); ok {
+		return named.Name()
 	}
-	defer file.Close()
+	return "input"
+}
+
+const maxLineLength = 1024 * 1024
 
-	scanner := bufio.NewScanner(file)
+func scanLines(r io.Reader, handle func(line string, lineNumber int) error) error {
+	name := InputName(r)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+	for lineNumber := 0; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if err := handle(line, lineNumber); err != nil {
+			return &ParseError{name, lineNumber + 1, line, err}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+func ProcessReader[T any, R any](r io.Reader, seed R, parseLine func(string) (T, error), join func(R, T) R) (R, error) {
+	parseLineIgnoringNumbers := func(line string, _ int) (T, error) {
+		return parseLine(line)
+	}
+	return ProcessReaderWithLineNumbers(r, seed, parseLineIgnoringNumbers, join)
+}
+
+func ProcessReaderWithLineNumbers[T any, R any](r io.Reader, seed R, parseLine func(string, int) (T, error), join func(R, T) R) (R, error) {
 	result := seed
-	lineNumber := 0
-	for scanner.Scan() {
-		parsed := parseLine(scanner.Text(), lineNumber)
+	err := scanLines(r, func(line string, lineNumber int) error {
+		parsed, err := parseLine(line, lineNumber)
+		if err != nil {
+			return err
+		}
 		result = join(result, parsed)
-		lineNumber++
-	}
+		return nil
+	})
+	return result, err
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+// CheckGrid reports the first row that is not as wide as the first one,
+// assuming rows were read one per line.
+func CheckGrid[T any](r io.Reader, rows [][]T) error {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return &ParseError{Name: InputName(r), Line: 1, Err: errors.New("empty grid")}
 	}
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			err := fmt.Errorf("row is %d wide, expected %d", len(row), len(rows[0]))
+			return &ParseError{Name: InputName(r), Line: i + 1, Err: err}
+		}
+	}
+	return nil
+}
 
-	return result
+func Lift[T any](parse func(string) T) func(string) (T, error) {
+	return func(line string) (T, error) {
+		return parse(line), nil
+	}
 }
 
 func Sum(a int, b int) int {
@@ -66,15 +134,44 @@ func Fields(str string, spaces string) []string {
 	return strings.FieldsFunc(str, f)
 }
 
-func ParseNumbers(str string) []int {
-	fields := strings.Fields(str)
+func ParseNumbers(str string) ([]int, error) {
+	return ParseNumberFields(strings.Fields(str))
+}
+
+func ParseNumberFields(fields []string) ([]int, error) {
 	numbers := make([]int, len(fields))
 	for i, field := range fields {
 		number, err := strconv.Atoi(field)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		numbers[i] = number
 	}
-	return numbers
+	return numbers, nil
+}
+
+func Fold[T any, R any](elems []T, seed R, join func(R, T) R) R {
+	result := seed
+	for _, elem := range elems {
+		result = join(result, elem)
+	}
+	return result
+}
+
+func GCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func LCM(a, b int) int {
+	return a / GCD(a, b) * b
+}
+
+func Or[T any](value *T, fallback T) T {
+	if value == nil {
+		return fallback
+	}
+	return *value
 }
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// LevelTrace is below debug, for dumps of whole grids and states.
+const LevelTrace = slog.LevelDebug - 4
+
+type key struct{}
+
+// With returns a context whose solvers log to l.
+func With(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, key{}, l)
+}
+
+var discard = slog.New(discardHandler{})
+
+// From returns the logger of ctx, or one that drops everything.
+func From(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(key{}).(*slog.Logger); ok {
+		return l
+	}
+	return discard
+}
+
+func Trace(ctx context.Context, msg string, args ...any) {
+	From(ctx).Log(ctx, LevelTrace, msg, args...)
+}
+
+// Lazy defers building a value until a handler actually logs it.
+type Lazy func() string
+
+func (f Lazy) LogValue() slog.Value {
+	return slog.StringValue(f())
+}
+
+// ParseLevel accepts trace along with the slog level names.
+func ParseLevel(name string) (level slog.Level, err error) {
+	if strings.EqualFold(name, "trace") {
+		return LevelTrace, nil
+	}
+	err = level.UnmarshalText([]byte(name))
+	return
+}
+
+// New logs records at level and above to w, as JSON lines or as text.
+func New(w io.Writer, level slog.Level, json bool) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.LevelKey && attr.Value.Any() == LevelTrace {
+				attr.Value = slog.StringValue("TRACE")
+			}
+			return attr
+		},
+	}
+	if json {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
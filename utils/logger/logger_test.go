@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFromWithoutLogger(t *testing.T) {
+	if From(context.Background()).Enabled(context.Background(), slog.LevelError) {
+		t.Error("default logger is enabled")
+	}
+}
+
+func TestTraceLevel(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := With(context.Background(), New(&buf, LevelTrace, true))
+	Trace(ctx, "grid", "rows", 2)
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["level"] != "TRACE" || record["msg"] != "grid" || record["rows"] != 2.0 {
+		t.Errorf("got %v", record)
+	}
+}
+
+func TestLazy(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := With(context.Background(), New(&buf, slog.LevelDebug, false))
+	called := 0
+	value := Lazy(func() string {
+		called++
+		return "expensive"
+	})
+	Trace(ctx, "skipped", "value", value)
+	From(ctx).Debug("logged", "value", value)
+	if called != 1 || !strings.Contains(buf.String(), "value=expensive") {
+		t.Errorf("called %d times, logged %q", called, buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]slog.Level{"trace": LevelTrace, "TRACE": LevelTrace, "debug": slog.LevelDebug, "warn": slog.LevelWarn} {
+		if got, err := ParseLevel(name); err != nil || got != want {
+			t.Errorf("%s: got %v, %v", name, got, err)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("loud is accepted")
+	}
+}
@@ -0,0 +1,31 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ANSI writes runes for a terminal, coloring the highlighted ones.
+type ANSI struct{}
+
+func (ANSI) Render(w io.Writer, picture Picture) error {
+	_, err := io.WriteString(w, ANSIString(picture))
+	return err
+}
+
+func ANSIString(picture Picture) string {
+	var sb strings.Builder
+	for _, row := range picture.Grid {
+		for _, cell := range row {
+			if cell.Color == nil {
+				sb.WriteRune(cell.Rune)
+				continue
+			}
+			r, g, b, _ := cell.Color.RGBA()
+			fmt.Fprintf(&sb, "\033[38;2;%d;%d;%dm%c\033[0m", r>>8, g>>8, b>>8, cell.Rune)
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
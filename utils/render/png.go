@@ -0,0 +1,41 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"unicode"
+)
+
+// PNG draws every cell as a square of CellSize pixels: highlighted cells in
+// their color, other cells gray unless their rune is a space or a '.'.
+type PNG struct {
+	CellSize int
+}
+
+var (
+	background = color.White
+	plain      = color.Gray{0xc0}
+)
+
+func (p PNG) Render(w io.Writer, picture Picture) error {
+	size := p.CellSize
+	img := image.NewRGBA(image.Rect(0, 0, picture.Width()*size, picture.Height()*size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	for i, row := range picture.Grid {
+		for j, cell := range row {
+			fill := cell.Color
+			if fill == nil {
+				if unicode.IsSpace(cell.Rune) || cell.Rune == '.' {
+					continue
+				}
+				fill = plain
+			}
+			rect := image.Rect(j*size, i*size, (j+1)*size, (i+1)*size)
+			draw.Draw(img, rect, image.NewUniform(fill), image.Point{}, draw.Src)
+		}
+	}
+	return png.Encode(w, img)
+}
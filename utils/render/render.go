@@ -0,0 +1,86 @@
+package render
+
+import (
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Cell is a rune highlighted with Color, or drawn plain if Color is nil.
+type Cell struct {
+	Rune  rune
+	Color color.Color
+}
+
+type Picture struct {
+	grid.Grid[Cell]
+}
+
+// Draw builds a picture with a cell for every cell of g.
+func Draw[T any](g grid.Grid[T], cell func(geom.Point, T) Cell) Picture {
+	picture := Picture{make(grid.Grid[Cell], len(g))}
+	for i, row := range g {
+		picture.Grid[i] = make([]Cell, len(row))
+		for j, value := range row {
+			picture.Grid[i][j] = cell(geom.Point{I: i, J: j}, value)
+		}
+	}
+	return picture
+}
+
+// Drawable is implemented by solvers that can picture their puzzle.
+type Drawable interface {
+	Draw() Picture
+}
+
+var (
+	Red   = color.RGBA{0xd0, 0x30, 0x30, 0xff}
+	Green = color.RGBA{0x30, 0xb0, 0x30, 0xff}
+	Blue  = color.RGBA{0x30, 0x60, 0xd0, 0xff}
+	Gold  = color.RGBA{0xe0, 0xb0, 0x20, 0xff}
+)
+
+type Renderer interface {
+	Render(w io.Writer, picture Picture) error
+}
+
+// ForFile picks the renderer by the extension of path, ANSI text by default.
+func ForFile(path string) Renderer {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		return SVG{CellSize: 12}
+	case ".png":
+		return PNG{CellSize: 4}
+	default:
+		return ANSI{}
+	}
+}
+
+func WriteFile(path string, picture Picture) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := ForFile(path).Render(file, picture); err != nil {
+		file.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Close()
+}
+
+// String is the picture without colors.
+func (picture Picture) String() string {
+	var sb strings.Builder
+	for _, row := range picture.Grid {
+		for _, cell := range row {
+			sb.WriteRune(cell.Rune)
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
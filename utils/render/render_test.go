@@ -0,0 +1,68 @@
+package render
+
+import (
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func sample() Picture {
+	return Draw(grid.Grid[rune]{[]rune("#.<"), []rune(".S.")}, func(p geom.Point, r rune) Cell {
+		if r == 'S' {
+			return Cell{r, Green}
+		}
+		return Cell{Rune: r}
+	})
+}
+
+func TestANSI(t *testing.T) {
+	want := "#.<\n.\033[38;2;48;176;48mS\033[0m.\n"
+	if got := ANSIString(sample()); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (SVG{CellSize: 10}).Render(&buf, sample()); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	for _, part := range []string{`width="30" height="20"`, `fill="#30b030"`, `&lt;</text>`} {
+		if !strings.Contains(svg, part) {
+			t.Errorf("%q is missing from %s", part, svg)
+		}
+	}
+}
+
+func TestPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (PNG{CellSize: 2}).Render(&buf, sample()); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size := img.Bounds().Size(); size.X != 6 || size.Y != 4 {
+		t.Errorf("got %v image", size)
+	}
+	if r, g, b, _ := img.At(3, 3).RGBA(); r>>8 != 0x30 || g>>8 != 0xb0 || b>>8 != 0x30 {
+		t.Errorf("start is colored %x %x %x", r, g, b)
+	}
+}
+
+func TestForFile(t *testing.T) {
+	if _, ok := ForFile("out.SVG").(SVG); !ok {
+		t.Error("svg")
+	}
+	if _, ok := ForFile("out.png").(PNG); !ok {
+		t.Error("png")
+	}
+	if _, ok := ForFile("out.txt").(ANSI); !ok {
+		t.Error("ansi")
+	}
+}
@@ -0,0 +1,46 @@
+package render
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"unicode"
+)
+
+// SVG draws every cell as a square of CellSize pixels with its rune inside.
+type SVG struct {
+	CellSize int
+}
+
+func hex(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+func (svg SVG) Render(w io.Writer, picture Picture) error {
+	size := svg.CellSize
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="%d" text-anchor="middle">`+"\n",
+		picture.Width()*size, picture.Height()*size, size*3/4)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	for i, row := range picture.Grid {
+		for j, cell := range row {
+			x, y := j*size, i*size
+			fill := "#000000"
+			if cell.Color != nil {
+				fill = "#ffffff"
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`, x, y, size, size, hex(cell.Color))
+			}
+			if !unicode.IsSpace(cell.Rune) {
+				fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s">`, x+size/2, y+size*4/5, fill)
+				xml.EscapeText(bw, []byte(string(cell.Rune)))
+				bw.WriteString("</text>")
+			}
+		}
+		bw.WriteString("\n")
+	}
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
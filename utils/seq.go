@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+func Map[T any, R any](seq iter.Seq[T], f func(T) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for elem := range seq {
+			if !yield(f(elem)) {
+				return
+			}
+		}
+	}
+}
+
+func Filter[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for elem := range seq {
+			if keep(elem) && !yield(elem) {
+				return
+			}
+		}
+	}
+}
+
+// Window yields every run of n consecutive elements, each in a new slice.
+// It panics if n is less than 1.
+func Window[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic(fmt.Sprintf("utils.Window: window size %d is less than 1", n))
+	}
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, n)
+		for elem := range seq {
+			if len(window) == n {
+				window = window[1:]
+			}
+			window = append(window, elem)
+			if len(window) == n && !yield(slices.Clone(window)) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk splits the sequence into slices of n elements, the last one can be shorter.
+// It panics if n is less than 1.
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	if n < 1 {
+		panic(fmt.Sprintf("utils.Chunk: chunk size %d is less than 1", n))
+	}
+	return func(yield func([]T) bool) {
+		var chunk []T
+		for elem := range seq {
+			chunk = append(chunk, elem)
+			if len(chunk) == n {
+				if !yield(chunk) {
+					return
+				}
+				chunk = nil
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+func Reduce[T any, R any](seq iter.Seq[T], seed R, join func(R, T) R) R {
+	result := seed
+	for elem := range seq {
+		result = join(result, elem)
+	}
+	return result
+}
+
+func Collect[T any](seq iter.Seq[T]) []T {
+	return slices.Collect(seq)
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// Stream reads an input lazily. It can be iterated only once, and Err
+// tells whether the iteration stopped early because of an error.
+type Stream struct {
+	r   io.Reader
+	err error
+}
+
+func NewStream(r io.Reader) *Stream {
+	return &Stream{r: r}
+}
+
+func (stream *Stream) Err() error {
+	return stream.err
+}
+
+// NumberedLines yields the lines along with their numbers, starting at 1.
+func (stream *Stream) NumberedLines() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		scanner := bufio.NewScanner(stream.r)
+		scanner.Buffer(nil, maxLineLength)
+		for lineNumber := 1; scanner.Scan(); lineNumber++ {
+			if !yield(lineNumber, scanner.Text()) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			stream.err = fmt.Errorf("%s: %w", InputName(stream.r), err)
+		}
+	}
+}
+
+func (stream *Stream) Lines() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, line := range stream.NumberedLines() {
+			if !yield(line) {
+				return
+			}
+		}
+	}
+}
+
+// Blocks yields the runs of lines separated by blank lines.
+func (stream *Stream) Blocks() iter.Seq[Block] {
+	return func(yield func(Block) bool) {
+		block := Block{Name: InputName(stream.r)}
+		for lineNumber, line := range stream.NumberedLines() {
+			if strings.TrimSpace(line) != "" {
+				if len(block.Lines) == 0 {
+					block.Line = lineNumber
+				}
+				block.Lines = append(block.Lines, line)
+				continue
+			}
+			if len(block.Lines) > 0 && !yield(block) {
+				return
+			}
+			block.Lines = nil
+		}
+		if len(block.Lines) > 0 {
+			yield(block)
+		}
+	}
+}
+
+// ParseLines yields the parsed lines and stops the stream at the first
+// line that does not parse.
+func ParseLines[T any](stream *Stream, parse func(string) (T, error)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for lineNumber, line := range stream.NumberedLines() {
+			parsed, err := parse(line)
+			if err != nil {
+				stream.err = &ParseError{InputName(stream.r), lineNumber, line, err}
+				return
+			}
+			if !yield(parsed) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestStreamLines(t *testing.T) {
+	stream := NewStream(strings.NewReader("a\nb\nc\n"))
+	var got []string
+	for lineNumber, line := range stream.NumberedLines() {
+		got = append(got, strconv.Itoa(lineNumber)+line)
+		if line == "b" {
+			break
+		}
+	}
+	if !slices.Equal(got, []string{"1a", "2b"}) || stream.Err() != nil {
+		t.Errorf("got %v, %v", got, stream.Err())
+	}
+}
+
+func TestStreamBlocks(t *testing.T) {
+	blocks := Collect(NewStream(strings.NewReader("a\n\nb\nc")).Blocks())
+	if len(blocks) != 2 || blocks[1].Line != 3 || !slices.Equal(blocks[1].Lines, []string{"b", "c"}) {
+		t.Errorf("got %+v", blocks)
+	}
+}
+
+func TestParseLines(t *testing.T) {
+	stream := NewStream(Named("numbers", strings.NewReader("1\n2\nx\n4\n")))
+	sum := Reduce(ParseLines(stream, strconv.Atoi), 0, Sum)
+	var parseErr *ParseError
+	if sum != 3 || !errors.As(stream.Err(), &parseErr) || parseErr.Line != 3 {
+		t.Errorf("got %d, %v", sum, stream.Err())
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	numbers := slices.Values([]int{1, 2, 3, 4, 5})
+	odd := func(n int) bool { return n%2 == 1 }
+	square := func(n int) int { return n * n }
+	if got := Collect(Map(Filter(numbers, odd), square)); !slices.Equal(got, []int{1, 9, 25}) {
+		t.Errorf("map filter: got %v", got)
+	}
+	windows := Collect(Window(numbers, 3))
+	if len(windows) != 3 || !slices.Equal(windows[0], []int{1, 2, 3}) || !slices.Equal(windows[2], []int{3, 4, 5}) {
+		t.Errorf("window: got %v", windows)
+	}
+	chunks := Collect(Chunk(numbers, 2))
+	if len(chunks) != 3 || !slices.Equal(chunks[2], []int{5}) {
+		t.Errorf("chunk: got %v", chunks)
+	}
+	for range Window(numbers, 6) {
+		t.Error("window longer than the sequence")
+	}
+}
+
+func TestCombinatorsSize(t *testing.T) {
+	for name, split := range map[string]func(iter.Seq[int], int) iter.Seq[[]int]{
+		"window": Window[int],
+		"chunk":  Chunk[int],
+	} {
+		for _, n := range []int{0, -1} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s of %d: expected a panic", name, n)
+					}
+				}()
+				split(slices.Values([]int{1, 2}), n)
+			}()
+		}
+	}
+}
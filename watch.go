@@ -0,0 +1,150 @@
+package main
+
+import (
+	"advent/registry"
+	"advent/watch"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func watchCommand(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	dayNum := flags.Int("day", 0, "day to watch")
+	year := flags.Int("year", registry.DefaultYear, "puzzle year")
+	part := flags.Int("part", 0, "part to run (default: both)")
+	input := flags.String("input", "", "input file, or NAME for input/YEAR/dayNN_NAME.txt (default: input/YEAR/dayNN.txt)")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to look for changes")
+	opts := make(registry.Options)
+	flags.Var(opts, "opt", "puzzle option as key=value, can be repeated (see advent list)")
+	flags.Parse(args)
+
+	day, found := registry.Get(*year, *dayNum)
+	if !found {
+		return fmt.Errorf("%d day %d is not registered", *year, *dayNum)
+	}
+	if _, err := selectParts(*part); err != nil {
+		return err
+	}
+	path := inputVariant(day, *input)
+	dir, err := os.MkdirTemp("", "advent-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	w := watcher{dir: dir, args: []string{"run", "--year", strconv.Itoa(day.Year), "--day", strconv.Itoa(day.Number), "--part", strconv.Itoa(*part), "--input", path, "--no-cache"}}
+	for key, value := range opts {
+		w.args = append(w.args, "--opt", key+"="+value)
+	}
+	w.rebuild = true
+	w.rerun(nil)
+	patterns := []string{
+		fmt.Sprintf("y%d/day%02d/*.go", day.Year, day.Number),
+		fmt.Sprintf("input/%d/day%02d*.txt", day.Year, day.Number),
+	}
+	err = watch.Poll(ctx, *interval, patterns, func(changed []string) {
+		for _, name := range changed {
+			if strings.HasSuffix(name, ".go") {
+				w.rebuild = true
+			}
+		}
+		w.rerun(changed)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// inputVariant accepts a path or the name of one of the day's inputs, so
+// test picks input/YEAR/dayNN_test.txt.
+func inputVariant(day registry.Day, name string) string {
+	if name == "" || name == "-" || strings.ContainsAny(name, `./\`) {
+		return cmp.Or(name, day.DefaultInput())
+	}
+	return strings.TrimSuffix(day.DefaultInput(), ".txt") + "_" + name + ".txt"
+}
+
+// watcher runs the day in a freshly built binary, so edits to the solver
+// take effect, and compares every run with the one before.
+type watcher struct {
+	dir      string
+	args     []string
+	rebuild  bool
+	previous *watch.Run
+}
+
+func (w *watcher) binary() string {
+	return filepath.Join(w.dir, "advent")
+}
+
+func (w *watcher) rerun(changed []string) {
+	if len(changed) > 0 {
+		fmt.Printf("\n%s changed\n", strings.Join(changed, ", "))
+	}
+	if w.rebuild {
+		build := exec.Command("go", "build", "-o", w.binary(), ".")
+		if output, err := build.CombinedOutput(); err != nil {
+			fmt.Printf("build failed: %v\n%s", err, output)
+			return
+		}
+		w.rebuild = false
+	}
+	run, err := w.run()
+	if err != nil {
+		fmt.Println("run failed:", err)
+		return
+	}
+	watch.Report(os.Stdout, run, w.previous)
+	w.previous = &run
+}
+
+// run reads the answers and timings from the JSON report of the binary.
+func (w *watcher) run() (run watch.Run, err error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(w.binary(), append(w.args, "--format", "json")...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		var exit *exec.ExitError
+		if !errors.As(err, &exit) {
+			return run, err
+		}
+		run.Err = strings.TrimSpace(strings.TrimPrefix(stderr.String(), "advent: "))
+	}
+	var rows []struct {
+		Part      int
+		Answer    *int
+		ParseMS   float64 `json:"parse_ms"`
+		ElapsedMS float64 `json:"duration_ms"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &rows); err != nil {
+		if run.Err != "" {
+			return run, errors.New(run.Err)
+		}
+		return run, err
+	}
+	run.Answers = make(map[int]int)
+	run.Solve = make(map[int]time.Duration)
+	for _, row := range rows {
+		run.Parse = time.Duration(row.ParseMS * float64(time.Millisecond))
+		if row.Answer != nil {
+			run.Answers[row.Part] = *row.Answer
+			run.Solve[row.Part] = time.Duration(row.ElapsedMS * float64(time.Millisecond))
+		}
+	}
+	return run, nil
+}
@@ -0,0 +1,64 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Run is what one run of a day printed and logged.
+type Run struct {
+	Answers map[int]int
+	Parse   time.Duration
+	Solve   map[int]time.Duration
+	Err     string
+}
+
+func formatDuration(now, then time.Duration, compare bool) string {
+	text := now.Round(time.Microsecond).String()
+	if compare && then > 0 {
+		text += fmt.Sprintf(" (%+.1f%%)", float64(now-then)/float64(then)*100)
+	}
+	return text
+}
+
+func formatAnswer(now, then int, compare bool) string {
+	if compare && now != then {
+		return fmt.Sprintf("%d (was %d)", now, then)
+	}
+	return fmt.Sprint(now)
+}
+
+// Report prints the answers and timings of the run, marking what changed
+// since the previous run unless there was none.
+func Report(w io.Writer, run Run, previous *Run) error {
+	var old Run
+	if previous != nil {
+		old = *previous
+	}
+	compare := previous != nil
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tanswer\ttime")
+	fmt.Fprintf(tw, "parse\t\t%s\n", formatDuration(run.Parse, old.Parse, compare))
+	parts := make([]int, 0, len(run.Answers))
+	for part := range run.Answers {
+		parts = append(parts, part)
+	}
+	slices.Sort(parts)
+	for _, part := range parts {
+		then, found := old.Answers[part]
+		fmt.Fprintf(tw, "part %d\t%s\t%s\n", part,
+			formatAnswer(run.Answers[part], then, compare && found),
+			formatDuration(run.Solve[part], old.Solve[part], compare))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if run.Err != "" {
+		_, err := fmt.Fprintln(w, "error:", run.Err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+type stamp struct {
+	modified time.Time
+	size     int64
+}
+
+// Files remembers what the watched files looked like when they were scanned.
+type Files map[string]stamp
+
+// Scan stats every file matching the glob patterns.
+func Scan(patterns ...string) (Files, error) {
+	files := make(Files)
+	for _, pattern := range patterns {
+		names, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			info, err := os.Stat(name)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			files[name] = stamp{info.ModTime(), info.Size()}
+		}
+	}
+	return files, nil
+}
+
+// Changed lists the files that were modified, created or removed since the
+// earlier scan, in order.
+func (files Files) Changed(earlier Files) (changed []string) {
+	for name, stamp := range files {
+		if earlier[name] != stamp {
+			changed = append(changed, name)
+		}
+	}
+	for name := range earlier {
+		if _, found := files[name]; !found {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return
+}
+
+// Poll scans the patterns every interval and calls onChange whenever some
+// of the files changed, until the context is done.
+func Poll(ctx context.Context, interval time.Duration, patterns []string, onChange func(changed []string)) error {
+	files, err := Scan(patterns...)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		next, err := Scan(patterns...)
+		if err != nil {
+			return err
+		}
+		if changed := next.Changed(files); len(changed) > 0 {
+			onChange(changed)
+		}
+		files = next
+	}
+}
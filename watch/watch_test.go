@@ -0,0 +1,66 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChanged(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, text string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	scan := func() Files {
+		files, err := Scan(filepath.Join(dir, "*.go"), filepath.Join(dir, "*.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return files
+	}
+	write("kept.go", "a")
+	edited := write("edited.txt", "a")
+	removed := write("removed.go", "a")
+	write("ignored.md", "a")
+	before := scan()
+	if len(before) != 3 {
+		t.Errorf("scanned %v", before)
+	}
+
+	write("edited.txt", "ab")
+	added := write("added.txt", "a")
+	os.Remove(removed)
+	changed := scan().Changed(before)
+	if want := []string{added, edited, removed}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("got %v, want %v", changed, want)
+	}
+	if changed := scan().Changed(scan()); len(changed) != 0 {
+		t.Errorf("changed without edits: %v", changed)
+	}
+}
+
+func TestReport(t *testing.T) {
+	previous := Run{Answers: map[int]int{1: 5, 2: 7}, Parse: time.Millisecond, Solve: map[int]time.Duration{1: 2 * time.Millisecond}}
+	run := Run{Answers: map[int]int{1: 5, 2: 8}, Parse: time.Millisecond, Solve: map[int]time.Duration{1: 3 * time.Millisecond}, Err: "broken"}
+	var out strings.Builder
+	if err := Report(&out, run, &previous); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"3ms (+50.0%)", "8 (was 7)", "error: broken"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report is missing %q:\n%s", want, out.String())
+		}
+	}
+	out.Reset()
+	Report(&out, run, nil)
+	if strings.Contains(out.String(), "was") || strings.Contains(out.String(), "%") {
+		t.Errorf("first report compares with nothing:\n%s", out.String())
+	}
+}
@@ -0,0 +1,94 @@
+package day01
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"io"
+	"strings"
+)
+
+var textToNumber = map[string]int{
+	"1":     1,
+	"2":     2,
+	"3":     3,
+	"4":     4,
+	"5":     5,
+	"6":     6,
+	"7":     7,
+	"8":     8,
+	"9":     9,
+	"0":     0,
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
+	"zero":  0,
+}
+
+func extractNumber(str string, spelled bool) int {
+	first_digit := 0
+	last_digit := 0
+	for pos := range str {
+		substr := str[pos:]
+		for text, number := range textToNumber {
+			if !spelled && len(text) > 1 {
+				continue
+			}
+			if strings.HasPrefix(substr, text) {
+				last_digit = number
+				if first_digit == 0 {
+					first_digit = last_digit
+				}
+			}
+		}
+	}
+	return first_digit*10 + last_digit
+}
+
+type Document []string
+
+func (document Document) sumNumbers(spelled bool) (sum int) {
+	for _, line := range document {
+		sum += extractNumber(line, spelled)
+	}
+	return
+}
+
+type Options struct {
+	Spelled *bool `opt:"spelled" usage:"also recognize spelled out digits (default: part 2 only)"`
+}
+
+type Calibration struct {
+	document Document
+	opts     Options
+}
+
+func (calibration Calibration) Part1(ctx context.Context) (int, error) {
+	return calibration.document.sumNumbers(utils.Or(calibration.opts.Spelled, false)), nil
+}
+
+func (calibration Calibration) Part2(ctx context.Context) (int, error) {
+	return calibration.document.sumNumbers(utils.Or(calibration.opts.Spelled, true)), nil
+}
+
+func appendLine(document Document, line string) Document {
+	return append(document, line)
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 1, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	document, err := utils.ProcessReader(r, Document{}, utils.Lift(utils.Identity[string]), appendLine)
+	if err != nil {
+		return nil, err
+	}
+	return Calibration{document, opts}, nil
+}
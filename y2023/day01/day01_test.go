@@ -0,0 +1,26 @@
+package day01
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 1,
+		golden.Case{Input: "day01_test.txt", Part1: 209, Part2: 281},
+		golden.Case{Input: "day01_test.txt", Options: registry.Options{"spelled": "false"}, Part2: 209},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 1)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 1)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 1)
+}
@@ -0,0 +1,34 @@
+package day01
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+)
+
+var spelledDigits = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+func init() {
+	gen.Register(2023, 1, Generate)
+}
+
+// Generate writes lines of letters, digits and spelled digits, each line
+// with at least one digit so that both parts have a value for it.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	for range size.Pick(20, 200, 1000) {
+		digit := rng.IntN(4)
+		for token := range 4 {
+			switch {
+			case token == digit:
+				w.WriteByte(byte('1' + rng.IntN(9)))
+			case rng.IntN(3) == 0:
+				w.WriteString(spelledDigits[rng.IntN(len(spelledDigits))])
+			default:
+				for range gen.Between(rng, 0, 5) {
+					w.WriteByte(byte('a' + rng.IntN(26)))
+				}
+			}
+		}
+		w.WriteByte('\n')
+	}
+}
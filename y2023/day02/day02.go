@@ -0,0 +1,139 @@
+package day02
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Cubes struct {
+	red   int
+	green int
+	blue  int
+}
+
+type Game struct {
+	id       int
+	cubesets []Cubes
+}
+
+func parseCubes(str string) (Cubes, error) {
+	cubes := Cubes{}
+	for _, cubeStr := range utils.Fields(str, ",") {
+		numAndColor := strings.Fields(cubeStr)
+		if len(numAndColor) != 2 {
+			return cubes, fmt.Errorf("expected number and color, got %q", cubeStr)
+		}
+		num, err := strconv.Atoi(numAndColor[0])
+		if err != nil {
+			return cubes, err
+		}
+		switch numAndColor[1] {
+		case "red":
+			cubes.red = num
+		case "green":
+			cubes.green = num
+		case "blue":
+			cubes.blue = num
+		default:
+			return cubes, fmt.Errorf("unknown color %q", numAndColor[1])
+		}
+	}
+	return cubes, nil
+}
+
+func parseGame(str string) (Game, error) {
+	idStr, cubesetsStr, found := strings.Cut(str, ":")
+	if !found {
+		return Game{}, errors.New("missing colon")
+	}
+	idStr, found = strings.CutPrefix(idStr, "Game ")
+	if !found {
+		return Game{}, errors.New("missing game id")
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return Game{}, err
+	}
+	cubesetsStrs := utils.Fields(cubesetsStr, ";")
+	cubesets := make([]Cubes, len(cubesetsStrs))
+	for i, cubesetStr := range cubesetsStrs {
+		if cubesets[i], err = parseCubes(cubesetStr); err != nil {
+			return Game{}, err
+		}
+	}
+	return Game{
+		id,
+		cubesets,
+	}, nil
+}
+
+func sumCorrectIds(bag Cubes) func(int, Game) int {
+	return func(acc int, game Game) int {
+		for _, cubeset := range game.cubesets {
+			if cubeset.red > bag.red || cubeset.green > bag.green || cubeset.blue > bag.blue {
+				return acc
+			}
+		}
+		return acc + game.id
+	}
+}
+
+func sumMinimalPowers(acc int, game Game) int {
+	minCubeset := Cubes{}
+	for _, cubeset := range game.cubesets {
+		if minCubeset.red < cubeset.red {
+			minCubeset.red = cubeset.red
+		}
+		if minCubeset.green < cubeset.green {
+			minCubeset.green = cubeset.green
+		}
+		if minCubeset.blue < cubeset.blue {
+			minCubeset.blue = cubeset.blue
+		}
+	}
+	minimalPower := minCubeset.red * minCubeset.green * minCubeset.blue
+	return acc + minimalPower
+}
+
+type Games []Game
+
+type Options struct {
+	Red   int `opt:"red" default:"12" min:"0" usage:"red cubes in the bag"`
+	Green int `opt:"green" default:"13" min:"0" usage:"green cubes in the bag"`
+	Blue  int `opt:"blue" default:"14" min:"0" usage:"blue cubes in the bag"`
+}
+
+type Record struct {
+	games Games
+	bag   Cubes
+}
+
+func (record Record) Part1(ctx context.Context) (int, error) {
+	return utils.Fold(record.games, 0, sumCorrectIds(record.bag)), nil
+}
+
+func (record Record) Part2(ctx context.Context) (int, error) {
+	return utils.Fold(record.games, 0, sumMinimalPowers), nil
+}
+
+func appendGame(games Games, game Game) Games {
+	return append(games, game)
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 2, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	games, err := utils.ProcessReader(r, Games{}, parseGame, appendGame)
+	if err != nil {
+		return nil, err
+	}
+	return Record{games, Cubes{opts.Red, opts.Green, opts.Blue}}, nil
+}
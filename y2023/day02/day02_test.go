@@ -0,0 +1,32 @@
+package day02
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 2,
+		golden.Case{Input: "day02_test.txt", Part1: 8, Part2: 2286},
+		golden.Case{Input: "day02_test.txt", Options: registry.Options{"red": "20", "green": "20", "blue": "20"}, Part1: 15},
+		golden.Case{Input: "day02_test.txt", Options: registry.Options{"red": "4"}, Part1: 3},
+		golden.Case{Input: "day02_test.txt", Options: registry.Options{"blue": "-1"}, Err: "option blue: -1 is less than 0"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 2)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 2)
+}
+
+func FuzzParseGame(f *testing.F) {
+	golden.FuzzLines(f, 2023, 2, parseGame)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 2)
+}
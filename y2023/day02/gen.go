@@ -0,0 +1,28 @@
+package day02
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+func init() {
+	gen.Register(2023, 2, Generate)
+}
+
+// Generate writes games of up to six draws of 1 to 20 cubes of each color.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	for id := range size.Pick(10, 40, 100) {
+		draws := make([]string, gen.Between(rng, 1, 6))
+		for i := range draws {
+			var cubes []string
+			for _, color := range rng.Perm(3)[:gen.Between(rng, 1, 3)] {
+				cubes = append(cubes, fmt.Sprintf("%d %s", gen.Between(rng, 1, 20), []string{"red", "green", "blue"}[color]))
+			}
+			draws[i] = strings.Join(cubes, ", ")
+		}
+		fmt.Fprintf(w, "Game %d: %s\n", id+1, strings.Join(draws, "; "))
+	}
+}
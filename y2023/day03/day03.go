@@ -0,0 +1,145 @@
+package day03
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"io"
+	"iter"
+)
+
+const (
+	Empty  = -1
+	Gear   = -2
+	Symbol = -2
+)
+
+// Row holds an index into numbers for every digit, or a marker for the
+// other cells.
+type Row struct {
+	cells   []int
+	numbers []int
+}
+
+// at treats everything around the row as empty.
+func (row Row) at(j int) int {
+	if j < 0 || j >= len(row.cells) {
+		return Empty
+	}
+	return row.cells[j]
+}
+
+func parseRow(line string) (row Row) {
+	row.cells = make([]int, len(line))
+	numIdx := -1
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if c >= '0' && c <= '9' {
+			if numIdx == -1 {
+				row.numbers = append(row.numbers, 0)
+				numIdx = len(row.numbers) - 1
+			}
+			row.numbers[numIdx] = row.numbers[numIdx]*10 + int(c-'0')
+			row.cells[i] = numIdx
+		} else {
+			numIdx = -1
+			switch c {
+			case '.':
+				row.cells[i] = Empty
+			case '*':
+				row.cells[i] = Gear
+			default:
+				row.cells[i] = Symbol
+			}
+		}
+	}
+	return
+}
+
+// padded surrounds the rows with empty ones, so every row gets to be in the
+// middle of a window.
+func padded(rows iter.Seq[Row]) iter.Seq[Row] {
+	return func(yield func(Row) bool) {
+		if !yield(Row{}) {
+			return
+		}
+		for row := range rows {
+			if !yield(row) {
+				return
+			}
+		}
+		yield(Row{})
+	}
+}
+
+type Scheme struct {
+	partNumbersSum int
+	gearRatiosSum  int
+}
+
+// addRow adds up the part numbers and gear ratios of the middle row of the window.
+func addRow(scheme Scheme, window []Row) Scheme {
+	row := window[1]
+	isPartNum := make([]bool, len(row.numbers))
+	for j, numIdx := range row.cells {
+		if numIdx < 0 {
+			continue
+		}
+		for _, other := range window {
+			for n := j - 1; n <= j+1; n++ {
+				if val := other.at(n); val == Gear || val == Symbol {
+					isPartNum[numIdx] = true
+				}
+			}
+		}
+	}
+	for i, num := range row.numbers {
+		if isPartNum[i] {
+			scheme.partNumbersSum += num
+		}
+	}
+	for j, val := range row.cells {
+		if val != Gear {
+			continue
+		}
+		partNumbers := map[[2]int]int{}
+		for m, other := range window {
+			for n := j - 1; n <= j+1; n++ {
+				if numIdx := other.at(n); numIdx >= 0 {
+					partNumbers[[2]int{m, numIdx}] = other.numbers[numIdx]
+				}
+			}
+		}
+		if len(partNumbers) != 2 {
+			continue
+		}
+		ratio := 1
+		for _, num := range partNumbers {
+			ratio *= num
+		}
+		scheme.gearRatiosSum += ratio
+	}
+	return scheme
+}
+
+func (scheme Scheme) Part1(ctx context.Context) (int, error) {
+	return scheme.partNumbersSum, nil
+}
+
+func (scheme Scheme) Part2(ctx context.Context) (int, error) {
+	return scheme.gearRatiosSum, nil
+}
+
+func init() {
+	registry.Register(2023, 3, Parse)
+}
+
+func Parse(r io.Reader) (registry.Solver, error) {
+	stream := utils.NewStream(r)
+	rows := utils.Map(stream.Lines(), parseRow)
+	scheme := utils.Reduce(utils.Window(padded(rows), 3), Scheme{}, addRow)
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	return scheme, nil
+}
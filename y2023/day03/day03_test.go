@@ -0,0 +1,24 @@
+package day03
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 3,
+		golden.Case{Input: "day03_test.txt", Part1: 4361, Part2: 467835},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 3)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 3)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 3)
+}
@@ -0,0 +1,39 @@
+package day03
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+)
+
+const symbols = "*#+$@/=%&-"
+
+func init() {
+	gen.Register(2023, 3, Generate)
+}
+
+// Generate writes a square schematic of numbers up to three digits long
+// scattered among symbols, a third of which are gears.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	side := size.Pick(10, 40, 140)
+	for range side {
+		row := make([]byte, 0, side+3)
+		for len(row) < side {
+			switch n := rng.IntN(10); {
+			case n < 2:
+				row = fmt.Appendf(row, "%d.", gen.Between(rng, 1, 999))
+			case n < 3:
+				if rng.IntN(3) == 0 {
+					row = append(row, '*')
+				} else {
+					row = append(row, symbols[rng.IntN(len(symbols))])
+				}
+			default:
+				row = append(row, '.')
+			}
+		}
+		w.Write(row[:side])
+		w.WriteByte('\n')
+	}
+}
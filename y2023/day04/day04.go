@@ -1,7 +1,11 @@
 package day04
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"context"
+	"errors"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -26,25 +30,30 @@ func (card Card) score() int {
 	return score
 }
 
-func parseCard(str string) Card {
+func parseCard(str string) (Card, error) {
 	parts := utils.Fields(str, ":|")
-	idStr, _ := strings.CutPrefix(parts[0], "Card ")
-	id, _ := strconv.Atoi(strings.TrimSpace(idStr))
+	if len(parts) != 3 {
+		return Card{}, errors.New("expected card id, winning numbers and your numbers")
+	}
+	idStr, found := strings.CutPrefix(parts[0], "Card ")
+	if !found {
+		return Card{}, errors.New("missing card id")
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(idStr))
+	if err != nil {
+		return Card{}, err
+	}
 	var numlists [2][]int
 	for i, numlistLine := range parts[1:3] {
-		numlistStr := strings.Fields(numlistLine)
-		numlist := make([]int, len(numlistStr))
-		for j, numStr := range numlistStr {
-			num, _ := strconv.Atoi(numStr)
-			numlist[j] = num
+		if numlists[i], err = utils.ParseNumbers(numlistLine); err != nil {
+			return Card{}, err
 		}
-		numlists[i] = numlist
 	}
 	return Card{
 		id,
 		numlists[0],
 		numlists[1],
-	}
+	}, nil
 }
 
 func cardsValueSum(acc int, card Card) int {
@@ -70,8 +79,25 @@ func numberOfCardsWon(acc Acc, card Card) Acc {
 	return Acc{acc.cardSum + multiplier, multipliers}
 }
 
-func Run() int {
-	//return utils.ProcessInput("day04.txt", 0, parseCard, cardsValueSum)
+type Cards []Card
+
+func (cards Cards) Part1(ctx context.Context) (int, error) {
+	return utils.Fold(cards, 0, cardsValueSum), nil
+}
+
+func (cards Cards) Part2(ctx context.Context) (int, error) {
 	acc := Acc{0, make(map[int]int)}
-	return utils.ProcessInput("day04.txt", acc, parseCard, numberOfCardsWon).cardSum
+	return utils.Fold(cards, acc, numberOfCardsWon).cardSum, nil
+}
+
+func appendCard(cards Cards, card Card) Cards {
+	return append(cards, card)
+}
+
+func init() {
+	registry.Register(2023, 4, Parse)
+}
+
+func Parse(r io.Reader) (registry.Solver, error) {
+	return utils.ProcessReader(r, Cards{}, parseCard, appendCard)
 }
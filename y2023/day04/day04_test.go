@@ -0,0 +1,28 @@
+package day04
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 4,
+		golden.Case{Input: "day04_test.txt", Part1: 13, Part2: 30},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 4)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 4)
+}
+
+func FuzzParseCard(f *testing.F) {
+	golden.FuzzLines(f, 2023, 4, parseCard)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 4)
+}
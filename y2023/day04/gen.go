@@ -0,0 +1,37 @@
+package day04
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 4, Generate)
+}
+
+// Generate writes cards with distinct numbers from 1 to 99 on both sides.
+// Only one card in four wins anything, which keeps the copies of part 2
+// from growing out of int range, and the last cards never win copies of
+// cards past the end of the table.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	cards := size.Pick(6, 50, 200)
+	winning, yours := size.Pick(5, 10, 10), size.Pick(8, 25, 25)
+	for id := 1; id <= cards; id++ {
+		numbers := rng.Perm(99)
+		matches := 0
+		if rng.IntN(4) == 0 {
+			matches = min(gen.Between(rng, 1, winning), cards-id)
+		}
+		fmt.Fprintf(w, "Card %3d:", id)
+		for _, n := range numbers[:winning] {
+			fmt.Fprintf(w, " %2d", n+1)
+		}
+		w.WriteString(" |")
+		for _, n := range numbers[winning-matches : winning-matches+yours] {
+			fmt.Fprintf(w, " %2d", n+1)
+		}
+		w.WriteByte('\n')
+	}
+}
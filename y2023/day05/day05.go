@@ -0,0 +1,238 @@
+package day05
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+type Range struct {
+	first int
+	len   int
+}
+
+func (r Range) last() int {
+	return r.first + r.len - 1
+}
+
+type RangeMapping struct {
+	dstFirst int
+	srcRange Range
+}
+
+type Mapping struct {
+	from   string
+	to     string
+	ranges []RangeMapping
+	line   int
+}
+
+func (mapping Mapping) lookup(srcRange Range) []Range {
+	rangeMappings := mapping.ranges
+	sort.Slice(rangeMappings, func(i, j int) bool {
+		return rangeMappings[i].srcRange.first < rangeMappings[j].srcRange.first
+	})
+	dstRanges := make([]Range, 0, 1)
+	for _, rm := range rangeMappings {
+		if rm.srcRange.last() < srcRange.first {
+			continue
+		}
+		if srcRange.last() < rm.srcRange.first {
+			break
+		}
+		if srcRange.first < rm.srcRange.first {
+			offset := rm.srcRange.first - srcRange.first
+			dstRanges = append(dstRanges, Range{srcRange.first, offset})
+			srcRange = Range{srcRange.first + offset, srcRange.len - offset}
+		}
+		offest := srcRange.first - rm.srcRange.first
+		if srcRange.last() <= rm.srcRange.last() {
+			dstRanges = append(dstRanges, Range{rm.dstFirst + offest, srcRange.len})
+			return dstRanges
+		}
+		insideLen := rm.srcRange.len - offest
+		dstRanges = append(dstRanges, Range{rm.dstFirst + offest, insideLen})
+		srcRange = Range{srcRange.first + insideLen, srcRange.len - insideLen}
+	}
+	dstRanges = append(dstRanges, srcRange)
+	return dstRanges
+}
+
+func (mapping Mapping) lookupAll(srcs []Range) []Range {
+	dstsNested := make([][]Range, len(srcs))
+	totalLen := 0
+	for i, src := range srcs {
+		dsts := mapping.lookup(src)
+		dstsNested[i] = dsts
+		totalLen += len(dsts)
+	}
+	dstsFlat := make([]Range, 0, totalLen)
+	for _, dsts := range dstsNested {
+		dstsFlat = append(dstsFlat, dsts...)
+	}
+	return dstsFlat
+}
+
+type Mappings []Mapping
+
+func (mappings Mappings) find(from string) (Mapping, bool) {
+	for _, mapping := range mappings {
+		if mapping.from == from {
+			return mapping, true
+		}
+	}
+	return Mapping{}, false
+}
+
+func (mappings Mappings) validateChain(name string, from string, to string) error {
+	visited := map[string]bool{from: true}
+	for curr := from; curr != to; {
+		mapping, found := mappings.find(curr)
+		if !found {
+			return fmt.Errorf("%s: no map from %s", name, curr)
+		}
+		curr = mapping.to
+		if visited[curr] {
+			text := fmt.Sprintf("%s-to-%s map:", mapping.from, mapping.to)
+			return &utils.ParseError{Name: name, Line: mapping.line, Text: text, Err: fmt.Errorf("maps loop back to %s", curr)}
+		}
+		visited[curr] = true
+	}
+	return nil
+}
+
+func (mappings Mappings) lookupChain(from string, to string, src Range) []Range {
+	curr := from
+	currRanges := []Range{src}
+	for curr != to {
+		mapping, _ := mappings.find(curr)
+		currRanges = mapping.lookupAll(currRanges)
+		curr = mapping.to
+	}
+	return currRanges
+}
+
+type Options struct {
+	SeedRanges *bool `opt:"seed-ranges" usage:"read seeds as start/length pairs (default: part 2 only)"`
+}
+
+type Almanac struct {
+	seeds    []int
+	mappings Mappings
+	opts     Options
+}
+
+func (almanac Almanac) seedRanges(inRangeFormat bool) (seeds []Range) {
+	if inRangeFormat {
+		seeds = make([]Range, len(almanac.seeds)/2)
+		for i := 0; i+1 < len(almanac.seeds); i += 2 {
+			seeds[i/2] = Range{almanac.seeds[i], almanac.seeds[i+1]}
+		}
+	} else {
+		seeds = make([]Range, len(almanac.seeds))
+		for i, seed := range almanac.seeds {
+			seeds[i] = Range{seed, 1}
+		}
+	}
+	return
+}
+
+func (almanac Almanac) minLocation(seeds []Range) int {
+	minLocation := math.MaxInt
+	for _, seedRange := range seeds {
+		locationRanges := almanac.mappings.lookupChain("seed", "location", seedRange)
+		for _, locationRange := range locationRanges {
+			if locationRange.first < minLocation {
+				minLocation = locationRange.first
+			}
+		}
+	}
+	return minLocation
+}
+
+func (almanac Almanac) Part1(ctx context.Context) (int, error) {
+	return almanac.minLocation(almanac.seedRanges(utils.Or(almanac.opts.SeedRanges, false))), nil
+}
+
+func (almanac Almanac) Part2(ctx context.Context) (int, error) {
+	return almanac.minLocation(almanac.seedRanges(utils.Or(almanac.opts.SeedRanges, true))), nil
+}
+
+func setSeeds(almanac Almanac, key, value string) (Almanac, error) {
+	if key != "seeds" {
+		return almanac, fmt.Errorf("expected seeds, found %s", key)
+	}
+	var err error
+	almanac.seeds, err = utils.ParseNumbers(value)
+	return almanac, err
+}
+
+var mappingHeaderRe = regexp.MustCompile(`^(\w+)-to-(\w+) map:$`)
+
+func parseMappingHeader(line string) (Mapping, error) {
+	match := mappingHeaderRe.FindStringSubmatch(line)
+	if match == nil {
+		return Mapping{}, errors.New("expected a map header")
+	}
+	return Mapping{from: match[1], to: match[2]}, nil
+}
+
+var mappingRangeRe = regexp.MustCompile(`^(\d+) (\d+) (\d+)$`)
+
+func parseMappingRange(line string) (RangeMapping, error) {
+	match := mappingRangeRe.FindStringSubmatch(line)
+	if match == nil {
+		return RangeMapping{}, errors.New("expected a range")
+	}
+	rangeNums := [3]int{}
+	for i, str := range match[1:] {
+		var err error
+		if rangeNums[i], err = strconv.Atoi(str); err != nil {
+			return RangeMapping{}, err
+		}
+	}
+	return RangeMapping{rangeNums[0], Range{rangeNums[1], rangeNums[2]}}, nil
+}
+
+// parseMapping keeps the line of the header to report loops in the chain.
+func parseMapping(almanac Almanac, block utils.Block) (Almanac, error) {
+	mapping, err := parseMappingHeader(block.Lines[0])
+	if err != nil {
+		return almanac, err
+	}
+	mapping.line = block.Line
+	for i, line := range block.Lines[1:] {
+		rangeMapping, err := parseMappingRange(line)
+		if err != nil {
+			return almanac, block.Err(i+1, err)
+		}
+		mapping.ranges = append(mapping.ranges, rangeMapping)
+	}
+	almanac.mappings = append(almanac.mappings, mapping)
+	return almanac, nil
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 5, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	almanac, err := utils.ProcessBlocks(r, Almanac{opts: opts},
+		utils.KeyValueSection(": ", setSeeds),
+		utils.BlockSection(parseMapping).Repeated(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := almanac.mappings.validateChain(utils.InputName(r), "seed", "location"); err != nil {
+		return nil, err
+	}
+	return almanac, nil
+}
@@ -0,0 +1,48 @@
+package day05
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"advent/utils"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 5,
+		golden.Case{Input: "day05_test.txt", Part1: 35, Part2: 46},
+		golden.Case{Input: "day05_test.txt", Options: registry.Options{"seed-ranges": "true"}, Part1: 46},
+	)
+}
+
+func TestParseErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"seeds: 1\n\nseed-to-a map:\n1 1 1\n\na-to-b map:\n1 1 1\n\nb-to-a map:\n1 1 1\n": `almanac:9: maps loop back to a (in "b-to-a map:")`,
+		"seeds: 1\n\nseed-to-a map:\n1 1 1\n\na-to-seed map:\n1 1 1\n":                    `almanac:6: maps loop back to seed (in "a-to-seed map:")`,
+		"seeds: 1\n\nseed-to-soil map:\n1 1 1\n\nsoil map:\n1 1 1\n":                      `almanac:6: expected a map header (in "soil map:")`,
+		"seeds: 1\n\nseed-to-soil map:\n1 1 1\n\nsoil-to-water map:\n1 1\n":               `almanac:7: expected a range (in "1 1")`,
+	} {
+		_, err := Parse(utils.Named("almanac", strings.NewReader(input)), Options{})
+		var parseErr *utils.ParseError
+		if !errors.As(err, &parseErr) || err.Error() != want {
+			t.Errorf("%q: got %v, want %s", input, err, want)
+		}
+	}
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 5)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 5)
+}
+
+func FuzzParseMappingRange(f *testing.F) {
+	golden.FuzzLines(f, 2023, 5, parseMappingRange)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 5)
+}
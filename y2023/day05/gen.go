@@ -0,0 +1,47 @@
+package day05
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+var chain = []string{"seed", "soil", "fertilizer", "water", "light", "temperature", "humidity", "location"}
+
+const limit = 1 << 32
+
+func init() {
+	gen.Register(2023, 5, Generate)
+}
+
+// Generate writes seed ranges and the chain of maps from seed to location.
+// Every map cuts the numbers below a random bound into ranges and shuffles
+// them, so that its source ranges never overlap, like in the real almanac.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	w.WriteString("seeds:")
+	for range size.Pick(2, 5, 10) {
+		first := rng.IntN(limit / 2)
+		fmt.Fprintf(w, " %d %d", first, gen.Between(rng, 1, limit/256))
+	}
+	w.WriteString("\n")
+	ranges := size.Pick(3, 15, 40)
+	for i := 1; i < len(chain); i++ {
+		fmt.Fprintf(w, "\n%s-to-%s map:\n", chain[i-1], chain[i])
+		bound := gen.Between(rng, limit/2, limit)
+		cuts := []int{0, bound}
+		for len(cuts) < ranges+1 {
+			if cut := rng.IntN(bound); !slices.Contains(cuts, cut) {
+				cuts = append(cuts, cut)
+			}
+		}
+		slices.Sort(cuts)
+		dst := 0
+		for _, j := range rng.Perm(ranges) {
+			length := cuts[j+1] - cuts[j]
+			fmt.Fprintf(w, "%d %d %d\n", dst, cuts[j], length)
+			dst += length
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package day06
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Race struct {
+	time     int
+	distance int
+}
+
+func (race Race) marginOfError() (wins int) {
+	for acceleration := 0; acceleration <= race.time; acceleration++ {
+		distance := acceleration * (race.time - acceleration)
+		if distance > race.distance {
+			wins++
+		}
+	}
+	return
+}
+
+type Races []Race
+
+func (races Races) marginOfError() (product int) {
+	product = 1
+	for _, race := range races {
+		product *= race.marginOfError()
+	}
+	return
+}
+
+type Options struct {
+	IgnoreSpaces *bool `opt:"ignore-spaces" usage:"read each line as a single number (default: part 2 only)"`
+}
+
+type Sheet struct {
+	races  Races
+	kerned Race
+	opts   Options
+}
+
+func (sheet Sheet) marginOfError(ignoreSpaces bool) int {
+	if ignoreSpaces {
+		return sheet.kerned.marginOfError()
+	}
+	return sheet.races.marginOfError()
+}
+
+func (sheet Sheet) Part1(ctx context.Context) (int, error) {
+	return sheet.marginOfError(utils.Or(sheet.opts.IgnoreSpaces, false)), nil
+}
+
+func (sheet Sheet) Part2(ctx context.Context) (int, error) {
+	return sheet.marginOfError(utils.Or(sheet.opts.IgnoreSpaces, true)), nil
+}
+
+func parseKerned(str string) (int, error) {
+	return strconv.Atoi(strings.ReplaceAll(str, " ", ""))
+}
+
+// Record is one line of the sheet, such as the times of all races.
+type Record struct {
+	name   string
+	values []int
+	kerned int
+}
+
+func parseRecord(line string) (record Record, err error) {
+	name, valuesStr, found := strings.Cut(line, ":")
+	if !found {
+		return record, errors.New("expected Time: or Distance:")
+	}
+	record.name = name
+	if record.values, err = utils.ParseNumbers(valuesStr); err != nil {
+		return
+	}
+	record.kerned, err = parseKerned(valuesStr)
+	return
+}
+
+func newSheet(times, distances Record, opts Options) (sheet Sheet, err error) {
+	if times.name != "Time" || distances.name != "Distance" {
+		return sheet, fmt.Errorf("expected Time and Distance, got %s and %s", times.name, distances.name)
+	}
+	if len(distances.values) != len(times.values) {
+		return sheet, fmt.Errorf("expected %d distances, got %d", len(times.values), len(distances.values))
+	}
+	sheet.races = make(Races, len(times.values))
+	for i := range sheet.races {
+		sheet.races[i] = Race{times.values[i], distances.values[i]}
+	}
+	sheet.kerned = Race{times.kerned, distances.kerned}
+	sheet.opts = opts
+	return
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 6, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	stream := utils.NewStream(r)
+	records := utils.Collect(utils.ParseLines(stream, parseRecord))
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	if len(records) != 2 {
+		return nil, fmt.Errorf("%s: expected 2 lines, got %d", utils.InputName(r), len(records))
+	}
+	sheet, err := newSheet(records[0], records[1], opts)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.InputName(r), err)
+	}
+	return sheet, nil
+}
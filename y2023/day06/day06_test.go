@@ -0,0 +1,27 @@
+package day06
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 6,
+		golden.Case{Input: "day06_test.txt", Part1: 288, Part2: 71503},
+		golden.Case{Input: "day06_test.txt", Options: registry.Options{"ignore-spaces": "true"}, Part1: 71503},
+		golden.Case{Input: "day06_test.txt", Options: registry.Options{"ignore-spaces": "false"}, Part2: 288},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 6)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 6)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 6)
+}
@@ -0,0 +1,34 @@
+package day06
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 6, Generate)
+}
+
+// Generate writes races with records that can be beaten. Times stay below
+// 100, so the kerned race of part 2 takes under 10^8 milliseconds.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	races := size.Pick(3, 3, 4)
+	times := make([]int, races)
+	distances := make([]int, races)
+	for i := range times {
+		times[i] = gen.Between(rng, 7, size.Pick(30, 60, 99))
+		best := (times[i] / 2) * (times[i] - times[i]/2)
+		distances[i] = gen.Between(rng, best/3, best-1)
+	}
+	w.WriteString("Time:    ")
+	for _, time := range times {
+		fmt.Fprintf(w, " %4d", time)
+	}
+	w.WriteString("\nDistance:")
+	for _, distance := range distances {
+		fmt.Fprintf(w, " %4d", distance)
+	}
+	w.WriteString("\n")
+}
@@ -1,7 +1,13 @@
 package day07
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -9,13 +15,11 @@ import (
 
 type Card rune
 
-const JOKERY_ENABLED = true
-
 const _RANKS_NORMAL = "23456789TJQKA"
 const _RANKS_JOKERY = "J23456789TQKA"
 
-func (card Card) rank() int {
-	if JOKERY_ENABLED {
+func (card Card) rank(jokers bool) int {
+	if jokers {
 		return strings.IndexRune(_RANKS_JOKERY, rune(card))
 	} else {
 		return strings.IndexRune(_RANKS_NORMAL, rune(card))
@@ -36,7 +40,7 @@ const (
 
 type Hand [5]Card
 
-func (hand Hand) handType() HandType {
+func (hand Hand) handType(jokers bool) HandType {
 	countCards := make(map[Card]uint8, 5)
 	for _, card := range hand {
 		countCards[card] += 1
@@ -48,7 +52,7 @@ func (hand Hand) handType() HandType {
 	for card, count := range countCards {
 		group := count - 1
 		countGroups[group]++
-		if JOKERY_ENABLED {
+		if jokers {
 			if card == 'J' {
 				jkrGroup = group
 			} else if topGroup == UNDEFINED || topGroup < group {
@@ -56,7 +60,7 @@ func (hand Hand) handType() HandType {
 			}
 		}
 	}
-	if JOKERY_ENABLED && jkrGroup != UNDEFINED && topGroup != UNDEFINED {
+	if jokers && jkrGroup != UNDEFINED && topGroup != UNDEFINED {
 		countGroups[jkrGroup]--
 		countGroups[topGroup]--
 		countGroups[jkrGroup+topGroup+1]++
@@ -81,15 +85,15 @@ func (hand Hand) handType() HandType {
 	}
 }
 
-func (this Hand) isWeakerThan(that Hand) bool {
-	thisHandType := this.handType()
-	thatHandType := that.handType()
+func (this Hand) isWeakerThan(that Hand, jokers bool) bool {
+	thisHandType := this.handType(jokers)
+	thatHandType := that.handType(jokers)
 	if thisHandType != thatHandType {
 		return thisHandType < thatHandType
 	}
 	for i := 0; i < 5; i++ {
 		if this[i] != that[i] {
-			return this[i].rank() < that[i].rank()
+			return this[i].rank(jokers) < that[i].rank(jokers)
 		}
 	}
 	return false
@@ -102,9 +106,10 @@ type Game struct {
 
 type Games []Game
 
-func (games Games) totalWinnings() (total int) {
+func (games Games) totalWinnings(jokers bool) (total int) {
+	games = slices.Clone(games)
 	sort.Slice(games, func(i, j int) bool {
-		return games[i].hand.isWeakerThan(games[j].hand)
+		return games[i].hand.isWeakerThan(games[j].hand, jokers)
 	})
 	for i, game := range games {
 		total += (i + 1) * game.bet
@@ -112,12 +117,35 @@ func (games Games) totalWinnings() (total int) {
 	return
 }
 
-func parseGame(line string) (game Game) {
-	for i, card := range line[0:5] {
+type Options struct {
+	Jokers *bool `opt:"jokers" usage:"rank J as the weakest wildcard (default: part 2 only)"`
+}
+
+type Tournament struct {
+	games Games
+	opts  Options
+}
+
+func (tournament Tournament) Part1(ctx context.Context) (int, error) {
+	return tournament.games.totalWinnings(utils.Or(tournament.opts.Jokers, false)), nil
+}
+
+func (tournament Tournament) Part2(ctx context.Context) (int, error) {
+	return tournament.games.totalWinnings(utils.Or(tournament.opts.Jokers, true)), nil
+}
+
+func parseGame(line string) (game Game, err error) {
+	handStr, betStr, found := strings.Cut(line, " ")
+	if !found || len(handStr) != len(game.hand) {
+		return game, errors.New("expected a hand of 5 cards and a bet")
+	}
+	for i, card := range handStr {
+		if !strings.ContainsRune(_RANKS_NORMAL, card) {
+			return game, fmt.Errorf("unknown card %q", card)
+		}
 		game.hand[i] = Card(card)
 	}
-	bet, _ := strconv.Atoi(line[6:])
-	game.bet = bet
+	game.bet, err = strconv.Atoi(betStr)
 	return
 }
 
@@ -125,7 +153,14 @@ func AppendGame(games Games, game Game) Games {
 	return append(games, game)
 }
 
-func Run() int {
-	games := utils.ProcessInput("day07.txt", nil, parseGame, AppendGame)
-	return games.totalWinnings()
+func init() {
+	registry.RegisterWithOptions(2023, 7, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	games, err := utils.ProcessReader(r, Games{}, parseGame, AppendGame)
+	if err != nil {
+		return nil, err
+	}
+	return Tournament{games, opts}, nil
 }
@@ -0,0 +1,30 @@
+package day07
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 7,
+		golden.Case{Input: "day07_test.txt", Part1: 6440, Part2: 5905},
+		golden.Case{Input: "day07_test.txt", Options: registry.Options{"jokers": "true"}, Part1: 5905},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 7)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 7)
+}
+
+func FuzzParseGame(f *testing.F) {
+	golden.FuzzLines(f, 2023, 7, parseGame)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 7)
+}
@@ -0,0 +1,24 @@
+package day07
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+	"strconv"
+)
+
+const cards = "23456789TJQKA"
+
+func init() {
+	gen.Register(2023, 7, Generate)
+}
+
+// Generate writes random hands with bids up to 1000.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	for range size.Pick(10, 200, 1000) {
+		for range 5 {
+			w.WriteByte(cards[rng.IntN(len(cards))])
+		}
+		w.WriteString(" " + strconv.Itoa(gen.Between(rng, 1, 1000)) + "\n")
+	}
+}
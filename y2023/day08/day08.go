@@ -0,0 +1,258 @@
+package day08
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/graph"
+	"advent/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+)
+
+type Direction byte
+
+const (
+	LEFT Direction = iota
+	RIGHT
+	SIZE
+)
+
+type Node string
+
+type Fork struct {
+	from  Node
+	left  Node
+	right Node
+}
+
+type DesertMap struct {
+	directions []Direction
+	forks      map[Node]Fork
+}
+
+type State struct {
+	directionIdx int
+	node         Node
+}
+
+// ExportGraph links every node to its left and right neighbors, with the
+// starting nodes of both parts in green and the targets in red.
+func (desertMap DesertMap) ExportGraph() *graph.Graph {
+	g := graph.New("desert", true)
+	for node, fork := range desertMap.forks {
+		switch {
+		case SuffixMatcher("A").matches(node):
+			g.AddNode(string(node), graph.Attrs{"color": "green"})
+		case SuffixMatcher("Z").matches(node):
+			g.AddNode(string(node), graph.Attrs{"color": "red"})
+		}
+		g.AddEdge(string(node), string(fork.left), graph.Attrs{"label": "L", "color": "green"})
+		g.AddEdge(string(node), string(fork.right), graph.Attrs{"label": "R", "color": "blue"})
+	}
+	g.Sort()
+	return g
+}
+
+// targetStateSteps walks from the node until it reaches a target in a state
+// it has been in before. A walk that comes back to a state without having
+// reached any target never will.
+func (desertMap DesertMap) targetStateSteps(ctx context.Context, from Node, target NodeMatcher) (stateToSteps map[State]int, err error) {
+	stateToSteps = make(map[State]int, 0)
+	visited := make(map[State]bool)
+	step := 0
+	node := from
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for idx, direction := range desertMap.directions {
+			if len(stateToSteps) == 0 {
+				state := State{directionIdx: idx, node: node}
+				if visited[state] {
+					return nil, fmt.Errorf("%s never reaches a target", from)
+				}
+				visited[state] = true
+			}
+			if target.matches(node) {
+				state := State{
+					directionIdx: idx,
+					node:         node,
+				}
+				_, exists := stateToSteps[state]
+				if exists {
+					return stateToSteps, nil
+				}
+				stateToSteps[state] = step
+			}
+			fork := desertMap.forks[node]
+			switch direction {
+			case LEFT:
+				node = fork.left
+			case RIGHT:
+				node = fork.right
+			}
+			step++
+		}
+	}
+}
+
+func (desertMap DesertMap) countGhostSteps(ctx context.Context, source NodeMatcher, target NodeMatcher) (stepCount int, err error) {
+	stepCount = 1
+	for sourceNode := range desertMap.forks {
+		if !source.matches(sourceNode) {
+			continue
+		}
+		stateToSteps, err := desertMap.targetStateSteps(ctx, sourceNode, target)
+		if err != nil {
+			return 0, err
+		}
+		firstSteps := math.MaxInt
+		for _, steps := range stateToSteps {
+			firstSteps = min(firstSteps, steps)
+		}
+		logger.From(ctx).Debug("ghost", "from", sourceNode, "first", firstSteps, "targets", len(stateToSteps))
+		stepCount = utils.LCM(stepCount, firstSteps)
+	}
+	return
+}
+
+type NodeMatcher interface {
+	matches(Node) bool
+}
+
+func allMatches(matcher NodeMatcher, nodes []Node) bool {
+	for _, node := range nodes {
+		if !matcher.matches(node) {
+			return false
+		}
+	}
+	return true
+}
+
+func (example Node) matches(node Node) bool {
+	return example == node
+}
+
+type SuffixMatcher string
+
+func (suffix SuffixMatcher) matches(node Node) bool {
+	return strings.HasSuffix(string(node), string(suffix))
+}
+
+func (desertMap DesertMap) countDirectionsSteps(ctx context.Context, fromMatching NodeMatcher, toMatching NodeMatcher) (stepCount int, err error) {
+	curr := make([]Node, 0)
+	for node := range desertMap.forks {
+		if fromMatching.matches(node) {
+			curr = append(curr, node)
+		}
+	}
+
+	stepCount = 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		for _, direction := range desertMap.directions {
+			if allMatches(toMatching, curr) {
+				return
+			}
+			for i, node := range curr {
+				fork := desertMap.forks[node]
+				switch direction {
+				case LEFT:
+					node = fork.left
+				case RIGHT:
+					node = fork.right
+				}
+				curr[i] = node
+			}
+			stepCount++
+		}
+	}
+}
+
+var directionsRe = regexp.MustCompile(`^[LR]+$`)
+var forkRe = regexp.MustCompile(`^(\w+) = \((\w+), (\w+)\)$`)
+
+func parseDirections(line string) ([]Direction, error) {
+	if !directionsRe.MatchString(line) {
+		return nil, errors.New("expected directions")
+	}
+	directions := make([]Direction, len(line))
+	for i, char := range line {
+		switch char {
+		case 'L':
+			directions[i] = LEFT
+		case 'R':
+			directions[i] = RIGHT
+		}
+	}
+	return directions, nil
+}
+
+func parseFork(line string) (Fork, error) {
+	match := forkRe.FindStringSubmatch(line)
+	if match == nil {
+		return Fork{}, errors.New("expected a fork")
+	}
+	return Fork{
+		from:  Node(match[1]),
+		left:  Node(match[2]),
+		right: Node(match[3]),
+	}, nil
+}
+
+func setDirections(desertMap DesertMap, directions []Direction) DesertMap {
+	desertMap.directions = directions
+	return desertMap
+}
+
+func addFork(desertMap DesertMap, fork Fork) DesertMap {
+	desertMap.forks[fork.from] = fork
+	return desertMap
+}
+
+func (desertMap DesertMap) Part1(ctx context.Context) (int, error) {
+	return desertMap.countDirectionsSteps(ctx, Node("AAA"), Node("ZZZ"))
+}
+
+func (desertMap DesertMap) Part2(ctx context.Context) (int, error) {
+	// Brute force with countDirectionsSteps(ctx, SuffixMatcher("A"), SuffixMatcher("Z"))
+	// takes forever, but the target states logged by countGhostSteps show
+	// every ghost keeps reaching targets with the period of its first target
+	return desertMap.countGhostSteps(ctx, SuffixMatcher("A"), SuffixMatcher("Z"))
+}
+
+func init() {
+	registry.Register(2023, 8, Parse)
+}
+
+func (desertMap DesertMap) validate() error {
+	for _, fork := range desertMap.forks {
+		for _, node := range []Node{fork.left, fork.right} {
+			if _, found := desertMap.forks[node]; !found {
+				return fmt.Errorf("node %s leads to unknown node %s", fork.from, node)
+			}
+		}
+	}
+	return nil
+}
+
+func Parse(r io.Reader) (registry.Solver, error) {
+	desertMap, err := utils.ProcessBlocks(r, DesertMap{forks: make(map[Node]Fork)},
+		utils.LineSection(parseDirections, setDirections),
+		utils.LinesSection(parseFork, addFork),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := desertMap.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.InputName(r), err)
+	}
+	return desertMap, nil
+}
@@ -0,0 +1,25 @@
+package day08
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 8,
+		golden.Case{Input: "day08_test.txt", Part2: 6},
+		golden.Case{Input: "day08_test2.txt", Err: "11A never reaches a target"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 8)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 8)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 8)
+}
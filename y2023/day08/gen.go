@@ -0,0 +1,68 @@
+package day08
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+func init() {
+	gen.Register(2023, 8, Generate)
+}
+
+// Generate writes a network of separate ghost loops, the first one leading
+// from AAA to ZZZ. Each step of a loop forks into two nodes that lead to the
+// same pair, so a ghost reaches its Z node every time it goes around
+// whatever the directions, and the answer of part 2 is the LCM of the loop
+// lengths.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	for range size.Pick(5, 61, 283) {
+		w.WriteByte("LR"[rng.IntN(2)])
+	}
+	w.WriteString("\n\n")
+
+	taken := map[string]bool{"AAA": true, "ZZZ": true}
+	name := func(last byte) string {
+		for {
+			name := fmt.Sprintf("%c%c%c", 'A'+rng.IntN(26), 'A'+rng.IntN(26), last)
+			if !taken[name] {
+				taken[name] = true
+				return name
+			}
+		}
+	}
+	middle := func() byte {
+		return byte('B' + rng.IntN(24))
+	}
+
+	var forks []string
+	for ghost := range size.Pick(2, 4, 6) {
+		start, end := "AAA", "ZZZ"
+		if ghost > 0 {
+			start, end = name('A'), name('Z')
+		}
+		pairs := make([][2]string, gen.Between(rng, 1, size.Pick(10, 40, 80)))
+		for i := range pairs {
+			pairs[i] = [2]string{name(middle()), name(middle())}
+		}
+		fork := func(from string, to [2]string) {
+			forks = append(forks, fmt.Sprintf("%s = (%s, %s)", from, to[0], to[1]))
+		}
+		fork(start, pairs[0])
+		fork(end, pairs[0])
+		for i, pair := range pairs {
+			next := [2]string{end, end}
+			if i+1 < len(pairs) {
+				next = pairs[i+1]
+			}
+			fork(pair[0], next)
+			fork(pair[1], next)
+		}
+	}
+	rng.Shuffle(len(forks), func(i, j int) {
+		forks[i], forks[j] = forks[j], forks[i]
+	})
+	w.WriteString(strings.Join(forks, "\n") + "\n")
+}
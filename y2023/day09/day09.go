@@ -1,8 +1,14 @@
 package day09
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"advent/utils/logger"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -50,17 +56,29 @@ func (history History) String() string {
 	return sb.String()
 }
 
+func (history History) clone() History {
+	cloned := make(History, len(history))
+	for i, row := range history {
+		cloned[i] = slices.Clone(row)
+	}
+	return cloned
+}
+
 func (history History) lastValue() int {
 	values := history[0]
 	return values[len(values)-1]
 }
 
-func parseLine(line string) (history History) {
+func parseLine(line string) (history History, err error) {
 	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, errors.New("empty history")
+	}
 	initial := make(Row, len(fields), 2*len(fields))
 	for i, field := range fields {
-		value, _ := strconv.Atoi(field)
-		initial[i] = value
+		if initial[i], err = strconv.Atoi(field); err != nil {
+			return
+		}
 	}
 	history = make(History, 1)
 	history[0] = initial
@@ -76,19 +94,39 @@ func parseLine(line string) (history History) {
 	return
 }
 
-func sumExtrapolatedForwardValues(acc int, history History) int {
-	fmt.Println(history)
-	history.extrapolateForward()
-	fmt.Println(history)
-	return acc + history.lastValue()
+func sumExtrapolatedForwardValues(ctx context.Context) func(int, History) int {
+	return func(acc int, history History) int {
+		history = history.clone()
+		history.extrapolateForward()
+		logger.Trace(ctx, "extrapolated", "history", logger.Lazy(history.String))
+		return acc + history.lastValue()
+	}
 }
 
 func sumExtrapolatedBackValues(acc int, history History) int {
+	history = history.clone()
 	history.extrapolateBack()
 	return acc + history[0][0]
 }
 
-func Run() int {
-	//return utils.ProcessInput("day09.txt", 0, parseLine, sumExtrapolatedForwardValues)
-	return utils.ProcessInput("day09.txt", 0, parseLine, sumExtrapolatedBackValues)
+type Report []History
+
+func (report Report) Part1(ctx context.Context) (int, error) {
+	return utils.Fold(report, 0, sumExtrapolatedForwardValues(ctx)), nil
+}
+
+func (report Report) Part2(ctx context.Context) (int, error) {
+	return utils.Fold(report, 0, sumExtrapolatedBackValues), nil
+}
+
+func appendHistory(report Report, history History) Report {
+	return append(report, history)
+}
+
+func init() {
+	registry.Register(2023, 9, Parse)
+}
+
+func Parse(r io.Reader) (registry.Solver, error) {
+	return utils.ProcessReader(r, Report{}, parseLine, appendHistory)
 }
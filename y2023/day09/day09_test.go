@@ -0,0 +1,24 @@
+package day09
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 9,
+		golden.Case{Input: "day09_test.txt", Part1: 114, Part2: 2},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 9)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 9)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 9)
+}
@@ -0,0 +1,35 @@
+package day09
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+	"strconv"
+)
+
+func init() {
+	gen.Register(2023, 9, Generate)
+}
+
+// Generate writes polynomial sequences. Their differences reach zero
+// before running out of values, so both parts can extrapolate them.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	length := size.Pick(6, 21, 21)
+	for range size.Pick(10, 100, 200) {
+		degree := gen.Between(rng, 0, min(length-2, 6))
+		diffs := make([]int, degree+1)
+		for i := range diffs {
+			diffs[i] = gen.Between(rng, -10, 30)
+		}
+		for i := range length {
+			if i > 0 {
+				w.WriteByte(' ')
+			}
+			w.WriteString(strconv.Itoa(diffs[0]))
+			for j := 0; j < degree; j++ {
+				diffs[j] += diffs[j+1]
+			}
+		}
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,276 @@
+package day10
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"advent/utils/logger"
+	"advent/utils/render"
+	"context"
+	"fmt"
+	"image/color"
+	"io"
+)
+
+type Pipe [2]geom.Dir
+
+var StartingPosition = Pipe{geom.Up, geom.Up}
+var Ground = Pipe{geom.Down, geom.Down}
+
+func parsePipe(r rune) (Pipe, error) {
+	switch r {
+	case '|':
+		return Pipe{geom.Up, geom.Down}, nil
+	case '-':
+		return Pipe{geom.Left, geom.Right}, nil
+	case 'L':
+		return Pipe{geom.Up, geom.Right}, nil
+	case 'J':
+		return Pipe{geom.Up, geom.Left}, nil
+	case '7':
+		return Pipe{geom.Left, geom.Down}, nil
+	case 'F':
+		return Pipe{geom.Right, geom.Down}, nil
+	case '.':
+		return Ground, nil
+	case 'S':
+		return StartingPosition, nil
+	default:
+		return Pipe{}, fmt.Errorf("unknown pipe %q", r)
+	}
+}
+
+func (pipe Pipe) isConnected(direction geom.Dir) bool {
+	if pipe[0] == pipe[1] {
+		return false
+	}
+	return pipe[0] == direction || pipe[1] == direction
+}
+
+type Color byte
+
+const (
+	NoColor Color = iota
+	LoopColor
+	InsideColor
+)
+
+type Tile struct {
+	pipe  Pipe
+	color Color
+}
+
+type Labyrinth struct {
+	tiles    grid.Grid[Tile]
+	position geom.Point
+}
+
+var pipeRunes = map[Pipe]rune{
+	{geom.Up, geom.Down}:    '║',
+	{geom.Left, geom.Right}: '═',
+	{geom.Up, geom.Right}:   '╚',
+	{geom.Up, geom.Left}:    '╝',
+	{geom.Left, geom.Down}:  '╗',
+	{geom.Right, geom.Down}: '╔',
+	Ground:                  '.',
+}
+
+func (labyrinth Labyrinth) String() string {
+	return render.ANSIString(render.Draw(labyrinth.tiles, func(_ geom.Point, tile Tile) render.Cell {
+		r, found := pipeRunes[tile.pipe]
+		if !found {
+			r = '?'
+		}
+		return render.Cell{Rune: r, Color: tileColors[tile.color]}
+	}))
+}
+
+var tileColors = map[Color]color.Color{
+	LoopColor:   render.Green,
+	InsideColor: render.Red,
+}
+
+func (labyrinth Labyrinth) LoopArea() render.Picture {
+	var picture render.Picture
+	for _, row := range labyrinth.tiles {
+		isInside := false
+		insideDirection := geom.Right
+		cells := make([]render.Cell, len(row))
+		for j, tile := range row {
+			cell := &cells[j]
+			cell.Color = tileColors[tile.color]
+			switch tile.color {
+			case NoColor:
+				cell.Rune = ' '
+			case LoopColor:
+				switch tile.pipe {
+				case Pipe{geom.Up, geom.Down}:
+					if isInside {
+						cell.Rune = '▌'
+					} else {
+						cell.Rune = '▐'
+					}
+					isInside = !isInside
+				case Pipe{geom.Left, geom.Right}:
+					if insideDirection == geom.Up {
+						cell.Rune = '▀'
+					} else {
+						cell.Rune = '▄'
+					}
+				case Pipe{geom.Up, geom.Right}:
+					if isInside {
+						cell.Rune = '▙'
+						insideDirection = geom.Down
+					} else {
+						cell.Rune = '▝'
+						insideDirection = geom.Up
+					}
+				case Pipe{geom.Up, geom.Left}:
+					if insideDirection == geom.Up {
+						cell.Rune = '▘'
+						isInside = false
+					} else {
+						cell.Rune = '▟'
+						isInside = true
+					}
+				case Pipe{geom.Left, geom.Down}:
+					if insideDirection == geom.Up {
+						cell.Rune = '▜'
+						isInside = true
+					} else {
+						cell.Rune = '▖'
+						isInside = false
+					}
+				case Pipe{geom.Right, geom.Down}:
+					if isInside {
+						cell.Rune = '▛'
+						insideDirection = geom.Up
+					} else {
+						cell.Rune = '▗'
+						insideDirection = geom.Down
+					}
+				default:
+					cell.Rune = '?'
+				}
+			case InsideColor:
+				cell.Rune = '█'
+			}
+		}
+		picture.Grid = append(picture.Grid, cells)
+	}
+	return picture
+}
+
+// Draw pictures the main loop and the area it encloses.
+func (labyrinth Labyrinth) Draw() render.Picture {
+	labyrinth.tiles = labyrinth.tiles.Clone()
+	labyrinth.colorMainLoop()
+	labyrinth.colorInsideTiles()
+	return labyrinth.LoopArea()
+}
+
+func (labyrinth Labyrinth) colorMainLoop() (length int) {
+	position := labyrinth.position
+	direction := labyrinth.tiles.At(position).pipe[0].Reverse()
+	for {
+		tile := &labyrinth.tiles[position.I][position.J]
+		tile.color = LoopColor
+		if tile.pipe[0].Reverse() != direction {
+			direction = tile.pipe[0]
+		} else {
+			direction = tile.pipe[1]
+		}
+		position = position.Step(direction)
+		length++
+		if position == labyrinth.position {
+			return
+		}
+	}
+}
+
+func (labyrinth Labyrinth) colorInsideTiles() (num int) {
+	for _, row := range labyrinth.tiles[1 : len(labyrinth.tiles)-1] {
+		isInside := false
+		lastUpOrDown := geom.Right
+		for j, tile := range row {
+			if tile.color == LoopColor {
+				switch tile.pipe {
+				case Pipe{geom.Up, geom.Down}:
+					isInside = !isInside
+				case Pipe{geom.Up, geom.Right}:
+					lastUpOrDown = geom.Up
+				case Pipe{geom.Right, geom.Down}:
+					lastUpOrDown = geom.Down
+				case Pipe{geom.Left, geom.Right}:
+				case Pipe{geom.Up, geom.Left}:
+					if lastUpOrDown == geom.Down {
+						isInside = !isInside
+					}
+				case Pipe{geom.Left, geom.Down}:
+					if lastUpOrDown == geom.Up {
+						isInside = !isInside
+					}
+				default:
+					panic("Unexpected pipe")
+				}
+			} else if isInside {
+				row[j].color = InsideColor
+				num++
+			}
+		}
+	}
+	return
+}
+
+func (labyrinth Labyrinth) inferStartPositionPipe() {
+	idx := 0
+	pipe := Pipe{}
+	for _, direction := range []geom.Dir{geom.Up, geom.Left, geom.Right} {
+		neighbor, found := labyrinth.tiles.Get(labyrinth.position.Step(direction))
+		if found && idx < 2 && neighbor.pipe.isConnected(direction.Reverse()) {
+			pipe[idx] = direction
+			idx++
+		}
+	}
+	if idx < 2 {
+		pipe[idx] = geom.Down
+	}
+	labyrinth.tiles.Set(labyrinth.position, Tile{pipe: pipe})
+}
+
+func parseTile(r rune) (Tile, error) {
+	pipe, err := parsePipe(r)
+	return Tile{pipe: pipe}, err
+}
+
+func (labyrinth Labyrinth) Part1(ctx context.Context) (int, error) {
+	return labyrinth.colorMainLoop() / 2, nil
+}
+
+func (labyrinth Labyrinth) Part2(ctx context.Context) (int, error) {
+	labyrinth.colorMainLoop()
+	inside := labyrinth.colorInsideTiles()
+	logger.Trace(ctx, "loop area", "area", logger.Lazy(labyrinth.LoopArea().String))
+	return inside, nil
+}
+
+func init() {
+	registry.Register(2023, 10, Parse)
+}
+
+func Parse(r io.Reader) (registry.Solver, error) {
+	tiles, err := grid.Parse(r, parseTile)
+	if err != nil {
+		return nil, err
+	}
+	start, found := tiles.Find(func(tile Tile) bool {
+		return tile.pipe == StartingPosition
+	})
+	if !found {
+		return nil, fmt.Errorf("%s: no starting position", utils.InputName(r))
+	}
+	labyrinth := Labyrinth{tiles, start}
+	labyrinth.inferStartPositionPipe()
+	return labyrinth, nil
+}
@@ -0,0 +1,24 @@
+package day10
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 10,
+		golden.Case{Input: "day10_test.txt", Part1: 80, Part2: 10},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 10)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 10)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 10)
+}
@@ -0,0 +1,87 @@
+package day10
+
+import (
+	"advent/gen"
+	"advent/utils/geom"
+	"bufio"
+	"math/rand/v2"
+)
+
+const junk = "|-LJ7F...."
+
+// pipes is indexed by the two directions a pipe connects.
+var pipes = map[[2]geom.Dir]byte{
+	{geom.Up, geom.Down}:    '|',
+	{geom.Left, geom.Right}: '-',
+	{geom.Up, geom.Right}:   'L',
+	{geom.Up, geom.Left}:    'J',
+	{geom.Left, geom.Down}:  '7',
+	{geom.Right, geom.Down}: 'F',
+}
+
+func init() {
+	gen.Register(2023, 10, Generate)
+}
+
+func direction(from, to geom.Point) geom.Dir {
+	for _, dir := range geom.Dirs {
+		if from.Step(dir) == to {
+			return dir
+		}
+	}
+	panic("cells are not adjacent")
+}
+
+// pipe connects the two directions, whichever order they come in.
+func pipe(a, b geom.Dir) byte {
+	if c, found := pipes[[2]geom.Dir{a, b}]; found {
+		return c
+	}
+	return pipes[[2]geom.Dir{b, a}]
+}
+
+// connects tells whether the pipe c has an end in the direction.
+func connects(c byte, dir geom.Dir) bool {
+	for dirs, p := range pipes {
+		if p == c && (dirs[0] == dir || dirs[1] == dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate writes a square field of junk pipes around a random loop that
+// goes through S. No junk pipe next to S points at it, so the pipe under S
+// can be told from its neighbors.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	side := size.Pick(10, 40, 140)
+	tiles := make([][]byte, side)
+	for i := range tiles {
+		tiles[i] = make([]byte, side)
+		for j := range tiles[i] {
+			tiles[i][j] = junk[rng.IntN(len(junk))]
+		}
+	}
+	loop := gen.Loop(rng, side, side)
+	onLoop := make(map[geom.Point]bool, len(loop))
+	for i, cell := range loop {
+		prev, next := loop[(i+len(loop)-1)%len(loop)], loop[(i+1)%len(loop)]
+		tiles[cell.I][cell.J] = pipe(direction(cell, prev), direction(cell, next))
+		onLoop[cell] = true
+	}
+	start := loop[rng.IntN(len(loop))]
+	tiles[start.I][start.J] = 'S'
+	for _, dir := range geom.Dirs {
+		p := start.Step(dir)
+		if p.I < 0 || p.I >= side || p.J < 0 || p.J >= side || onLoop[p] {
+			continue
+		}
+		if connects(tiles[p.I][p.J], dir.Reverse()) {
+			tiles[p.I][p.J] = '.'
+		}
+	}
+	for _, row := range tiles {
+		w.Write(row)
+		w.WriteByte('\n')
+	}
+}
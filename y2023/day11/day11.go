@@ -1,7 +1,12 @@
 package day11
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"context"
+	"fmt"
+	"io"
+	"slices"
 	"sort"
 )
 
@@ -57,11 +62,15 @@ func (galaxies Galaxies) distancePairwiseSum() (sum int) {
 	return
 }
 
-func parseGalaxiesRow(line string, y int) (xs Galaxies) {
+func parseGalaxiesRow(line string, y int) (xs Galaxies, err error) {
 	xs = make(Galaxies, 0)
 	for x, r := range line {
-		if r == '#' {
+		switch r {
+		case '#':
 			xs = append(xs, Galaxy{x: x, y: y})
+		case '.':
+		default:
+			return nil, fmt.Errorf("unexpected %q", r)
 		}
 	}
 	return
@@ -71,8 +80,37 @@ func appendAll(galaxies Galaxies, galaxiesRow Galaxies) Galaxies {
 	return append(galaxies, galaxiesRow...)
 }
 
-func Run() int {
-	galaxies := utils.ProcessInputWithLineNumbers("day11.txt", nil, parseGalaxiesRow, appendAll)
-	galaxies.expand(1000000)
+func (galaxies Galaxies) distancePairwiseSumExpanded(factor int) int {
+	galaxies = slices.Clone(galaxies)
+	galaxies.expand(factor)
 	return galaxies.distancePairwiseSum()
 }
+
+type Options struct {
+	Expansion *int `opt:"expansion" min:"1" usage:"how many times empty rows and columns grow (default: 2 for part 1, 1000000 for part 2)"`
+}
+
+type Image struct {
+	galaxies Galaxies
+	opts     Options
+}
+
+func (image Image) Part1(ctx context.Context) (int, error) {
+	return image.galaxies.distancePairwiseSumExpanded(utils.Or(image.opts.Expansion, 2)), nil
+}
+
+func (image Image) Part2(ctx context.Context) (int, error) {
+	return image.galaxies.distancePairwiseSumExpanded(utils.Or(image.opts.Expansion, 1000000)), nil
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 11, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	galaxies, err := utils.ProcessReaderWithLineNumbers(r, Galaxies{}, parseGalaxiesRow, appendAll)
+	if err != nil {
+		return nil, err
+	}
+	return Image{galaxies, opts}, nil
+}
@@ -0,0 +1,27 @@
+package day11
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 11,
+		golden.Case{Input: "day11_test.txt", Part1: 374, Part2: 82000210},
+		golden.Case{Input: "day11_test.txt", Options: registry.Options{"expansion": "10"}, Part1: 1030, Part2: 1030},
+		golden.Case{Input: "day11_test.txt", Options: registry.Options{"expansion": "100"}, Part1: 8410, Part2: 8410},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 11)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 11)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 11)
+}
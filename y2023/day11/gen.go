@@ -0,0 +1,42 @@
+package day11
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 11, Generate)
+}
+
+// Generate writes a square image with some empty rows and columns to
+// expand and a few galaxies in the rest, always at least two.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	side := size.Pick(10, 40, 140)
+	emptyRow := make([]bool, side)
+	emptyColumn := make([]bool, side)
+	for i := range side {
+		emptyRow[i] = rng.IntN(8) == 0
+		emptyColumn[i] = rng.IntN(8) == 0
+	}
+	image := make([][]byte, side)
+	galaxies := 0
+	for i := range image {
+		image[i] = make([]byte, side)
+		for j := range image[i] {
+			image[i][j] = '.'
+			if !emptyRow[i] && !emptyColumn[j] && rng.IntN(25) == 0 {
+				image[i][j] = '#'
+				galaxies++
+			}
+		}
+	}
+	for ; galaxies < 2; galaxies++ {
+		image[galaxies][galaxies] = '#'
+	}
+	for _, row := range image {
+		w.Write(row)
+		w.WriteByte('\n')
+	}
+}
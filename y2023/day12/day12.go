@@ -0,0 +1,186 @@
+package day12
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Condition rune
+
+const (
+	Operational Condition = '.'
+	Broken      Condition = '#'
+	Unknown     Condition = '?'
+)
+
+type ConditionRecord struct {
+	brokenSeries []int
+	conditions   []Condition
+}
+
+func (record ConditionRecord) String() string {
+	var sb strings.Builder
+	sb.WriteRune('[')
+	for _, c := range record.conditions {
+		sb.WriteRune(rune(c))
+	}
+	for _, s := range record.brokenSeries {
+		sb.WriteRune(' ')
+		fmt.Fprintf(&sb, "%d", s)
+	}
+	sb.WriteRune(']')
+	return sb.String()
+}
+
+// memo keeps the arrangements of the records seen during one solve, so
+// concurrent solves share nothing and every solve starts from scratch.
+type memo map[string]int
+
+func (memo memo) numberOfArrangements(record ConditionRecord) int {
+	if cached, ok := memo[record.String()]; ok {
+		return cached
+	}
+	number := memo.calculateNumberOfArrangements(record.brokenSeries, record.conditions)
+	memo[record.String()] = number
+	return number
+}
+
+func (memo memo) calculateNumberOfArrangements(brokenSeries []int, conditions []Condition) int {
+	if len(brokenSeries) == 0 {
+		for _, c := range conditions {
+			if c == Broken {
+				return 0
+			}
+		}
+		return 1
+	}
+	currentSeriesLen := brokenSeries[0]
+	for {
+		if len(conditions) < currentSeriesLen+1 {
+			return 0
+		}
+		if conditions[0] != Operational {
+			break
+		}
+		conditions = conditions[1:]
+	}
+
+	canBePlacedHere := true
+	if conditions[currentSeriesLen] == Broken {
+		canBePlacedHere = false
+	} else {
+		for _, c := range conditions[0:currentSeriesLen] {
+			if c == Operational {
+				canBePlacedHere = false
+				break
+			}
+		}
+	}
+	canBePlacedElsewhere := conditions[0] != Broken
+
+	number := 0
+	if canBePlacedHere {
+		number += memo.numberOfArrangements(ConditionRecord{
+			brokenSeries: brokenSeries[1:],
+			conditions:   conditions[currentSeriesLen+1:],
+		})
+	}
+	if canBePlacedElsewhere {
+		number += memo.numberOfArrangements(ConditionRecord{
+			brokenSeries: brokenSeries,
+			conditions:   conditions[1:],
+		})
+	}
+	return number
+}
+
+func parseConditionRecord(line string) (record ConditionRecord, err error) {
+	split := strings.Fields(line)
+	if len(split) != 2 {
+		return record, errors.New("expected conditions and broken series")
+	}
+	conditionsStr := split[0]
+	record.conditions = make([]Condition, 0, len(conditionsStr)+1)
+	for _, r := range conditionsStr {
+		switch condition := Condition(r); condition {
+		case Operational, Broken, Unknown:
+			record.conditions = append(record.conditions, condition)
+		default:
+			return record, fmt.Errorf("unknown condition %q", r)
+		}
+	}
+	record.conditions = append(record.conditions, Operational)
+	brokenSeriesFields := utils.Fields(split[1], ",")
+	record.brokenSeries = make([]int, len(brokenSeriesFields))
+	for i, numStr := range brokenSeriesFields {
+		if record.brokenSeries[i], err = strconv.Atoi(numStr); err != nil {
+			return
+		}
+		if record.brokenSeries[i] <= 0 {
+			return record, fmt.Errorf("broken series length %d is not positive", record.brokenSeries[i])
+		}
+	}
+	return
+}
+
+func (record ConditionRecord) multiply(n int) (result ConditionRecord) {
+	result.conditions = make([]Condition, 0, len(record.conditions)*n)
+	result.brokenSeries = make([]int, 0, len(record.brokenSeries)*n)
+	for i := 0; i < n; i++ {
+		result.conditions = append(result.conditions, record.conditions...)
+		if i < n-1 {
+			result.conditions[len(result.conditions)-1] = Unknown
+		}
+		result.brokenSeries = append(result.brokenSeries, record.brokenSeries...)
+	}
+	return
+}
+
+type Options struct {
+	Folds int `opt:"folds" default:"5" min:"1" usage:"how many copies of each record part 2 unfolds"`
+}
+
+type ConditionRecords []ConditionRecord
+
+type Report struct {
+	records ConditionRecords
+	opts    Options
+}
+
+func (records ConditionRecords) sumOfArrangements(multiplier int) (sum int) {
+	memo := make(memo)
+	for _, record := range records {
+		sum += memo.numberOfArrangements(record.multiply(multiplier))
+	}
+	return
+}
+
+func (report Report) Part1(ctx context.Context) (int, error) {
+	return report.records.sumOfArrangements(1), nil
+}
+
+func (report Report) Part2(ctx context.Context) (int, error) {
+	return report.records.sumOfArrangements(report.opts.Folds), nil
+}
+
+func aggregate(records ConditionRecords, record ConditionRecord) ConditionRecords {
+	return append(records, record)
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 12, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	records, err := utils.ProcessReader(r, ConditionRecords{}, parseConditionRecord, aggregate)
+	if err != nil {
+		return nil, err
+	}
+	return Report{records, opts}, nil
+}
@@ -0,0 +1,32 @@
+package day12
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 12,
+		golden.Case{Input: "day12_test.txt", Part1: 21, Part2: 525152},
+		golden.Case{Input: "day12_test.txt", Options: registry.Options{"folds": "1"}, Part2: 21},
+		golden.Case{Input: "day12_test.txt", Options: registry.Options{"folds": "2"}, Part2: 206},
+		golden.Case{Input: "day12_test.txt", Options: registry.Options{"folds": "-1"}, Err: "option folds: -1 is less than 1"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 12)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 12)
+}
+
+func FuzzParseConditionRecord(f *testing.F) {
+	golden.FuzzLines(f, 2023, 12, parseConditionRecord)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 12)
+}
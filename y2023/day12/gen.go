@@ -0,0 +1,51 @@
+package day12
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	gen.Register(2023, 12, Generate)
+}
+
+// Generate writes records of a known arrangement of springs with some of
+// them hidden, so every record has at least that arrangement. Records have
+// at most six groups and at most half of their springs hidden, which keeps
+// the unfolded arrangements of part 2 well within an int.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	for range size.Pick(6, 100, 1000) {
+		springs := make([]byte, gen.Between(rng, 4, size.Pick(10, 20, 20)))
+		var groups []string
+		run := 0
+		for i := range springs {
+			springs[i] = '.'
+			extend := i > 0 && springs[i-1] == '#'
+			if rng.IntN(5) < 3 && (extend || len(groups) < 6 && rng.IntN(2) == 0) {
+				springs[i] = '#'
+				run++
+			} else if run > 0 {
+				groups = append(groups, strconv.Itoa(run))
+				run = 0
+			}
+		}
+		if run > 0 {
+			groups = append(groups, strconv.Itoa(run))
+		}
+		if len(groups) == 0 {
+			springs[0] = '#'
+			groups = append(groups, "1")
+		}
+		hidden := rng.Float64() / 2
+		for i := range springs {
+			if rng.Float64() < hidden {
+				springs[i] = '?'
+			}
+		}
+		w.Write(springs)
+		w.WriteString(" " + strings.Join(groups, ",") + "\n")
+	}
+}
@@ -0,0 +1,148 @@
+package day13
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"advent/utils/logger"
+	"advent/utils/render"
+	"context"
+	"fmt"
+	"io"
+)
+
+type Land rune
+
+const (
+	ASH  Land = '.'
+	ROCK Land = '#'
+)
+
+type Pattern struct {
+	grid.Grid[Land]
+}
+
+func (pattern Pattern) String() string {
+	return pattern.Render(grid.Runes[Land])
+}
+
+func (pattern Pattern) findVerticalReflection(smudges int) int {
+	return Pattern{pattern.Transpose()}.findHorizontalReflection(smudges)
+}
+
+func (pattern Pattern) findHorizontalReflection(smudges int) int {
+	h, w := pattern.Height(), pattern.Width()
+REFLECTION_INDEX:
+	for index := 1; index < h; index++ {
+		smudgeCount := 0
+		for i1, i2 := index-1, index; i1 >= 0 && i2 < h; i1, i2 = i1-1, i2+1 {
+			for j := 0; j < w; j++ {
+				if pattern.Grid[i1][j] != pattern.Grid[i2][j] {
+					if smudgeCount == smudges {
+						continue REFLECTION_INDEX
+					} else {
+						smudgeCount++
+					}
+				}
+			}
+		}
+		if smudgeCount == smudges {
+			return index
+		}
+	}
+	return 0
+}
+
+func parseLand(r rune) (Land, error) {
+	switch land := Land(r); land {
+	case ASH, ROCK:
+		return land, nil
+	default:
+		return 0, fmt.Errorf("unknown land %q", r)
+	}
+}
+
+func appendPattern(patterns Patterns, land grid.Grid[Land]) Patterns {
+	return append(patterns, Pattern{land})
+}
+
+type Options struct {
+	Smudges *int `opt:"smudges" min:"0" usage:"differences a reflection must have (default: 0 for part 1, 1 for part 2)"`
+}
+
+type Patterns []Pattern
+
+type Notes struct {
+	patterns Patterns
+	opts     Options
+}
+
+func (patterns Patterns) summarize(ctx context.Context, smudges int) (total int) {
+	for _, pattern := range patterns {
+		summary := pattern.findVerticalReflection(smudges) + 100*pattern.findHorizontalReflection(smudges)
+		if summary == 0 {
+			logger.From(ctx).Warn("no reflection", "smudges", smudges, "pattern", logger.Lazy(pattern.String))
+		}
+		total += summary
+	}
+	return
+}
+
+// picture highlights the rocks next to the reflection line.
+func (pattern Pattern) picture(smudges int) render.Picture {
+	column, row := pattern.findVerticalReflection(smudges), pattern.findHorizontalReflection(smudges)
+	return render.Draw(pattern.Grid, func(p geom.Point, land Land) render.Cell {
+		cell := render.Cell{Rune: rune(land)}
+		if land == ROCK && (column > 0 && (p.J == column-1 || p.J == column) || row > 0 && (p.I == row-1 || p.I == row)) {
+			cell.Color = render.Gold
+		}
+		return cell
+	})
+}
+
+// Draw pictures the patterns one below the other with the reflections of
+// part 1.
+func (notes Notes) Draw() render.Picture {
+	width := 0
+	for _, pattern := range notes.patterns {
+		width = max(width, pattern.Width())
+	}
+	blank := func(n int) []render.Cell {
+		cells := make([]render.Cell, n)
+		for j := range cells {
+			cells[j].Rune = ' '
+		}
+		return cells
+	}
+	var picture render.Picture
+	for n, pattern := range notes.patterns {
+		if n > 0 {
+			picture.Grid = append(picture.Grid, blank(width))
+		}
+		for _, row := range pattern.picture(utils.Or(notes.opts.Smudges, 0)).Grid {
+			picture.Grid = append(picture.Grid, append(row, blank(width-len(row))...))
+		}
+	}
+	return picture
+}
+
+func (notes Notes) Part1(ctx context.Context) (int, error) {
+	return notes.patterns.summarize(ctx, utils.Or(notes.opts.Smudges, 0)), nil
+}
+
+func (notes Notes) Part2(ctx context.Context) (int, error) {
+	return notes.patterns.summarize(ctx, utils.Or(notes.opts.Smudges, 1)), nil
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 13, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	patterns, err := utils.ProcessBlocks(r, Patterns{}, grid.Section(parseLand, appendPattern).Repeated())
+	if err != nil {
+		return nil, err
+	}
+	return Notes{patterns, opts}, nil
+}
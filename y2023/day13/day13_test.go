@@ -0,0 +1,27 @@
+package day13
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 13,
+		golden.Case{Input: "day13_test.txt", Part1: 405, Part2: 400},
+		golden.Case{Input: "day13_test.txt", Options: registry.Options{"smudges": "1"}, Part1: 400},
+		golden.Case{Input: "day13_test.txt", Options: registry.Options{"smudges": "0"}, Part2: 405},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 13)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 13)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 13)
+}
@@ -0,0 +1,93 @@
+package day13
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+	"slices"
+)
+
+func init() {
+	gen.Register(2023, 13, Generate)
+}
+
+// Generate writes patterns that mirror perfectly at one place and with a
+// single smudge at another, and nowhere else with fewer than two smudges.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	for n := range size.Pick(4, 50, 100) {
+		if n > 0 {
+			w.WriteByte('\n')
+		}
+		for _, row := range pattern(rng) {
+			w.Write(row)
+			w.WriteByte('\n')
+		}
+	}
+}
+
+// pattern mirrors the rows next to the top edge and, with a smudge, the
+// rows next to the bottom edge, then turns the pattern around at random.
+func pattern(rng *rand.Rand) [][]byte {
+	for {
+		h, w := gen.Between(rng, 5, 17), gen.Between(rng, 5, 17)
+		rows := make([][]byte, h)
+		for i := range rows {
+			rows[i] = make([]byte, w)
+			for j := range rows[i] {
+				rows[i][j] = ".#"[rng.IntN(2)]
+			}
+		}
+		perfect := gen.Between(rng, 1, h/2-1)
+		smudged := gen.Between(rng, 1, (h-2*perfect)/2)
+		for k := range perfect {
+			copy(rows[2*perfect-1-k], rows[k])
+		}
+		for k := range smudged {
+			copy(rows[h-1-k], rows[h-2*smudged+k])
+		}
+		smudge := rows[h-1-rng.IntN(smudged)]
+		j := rng.IntN(w)
+		smudge[j] = '.' + '#' - smudge[j]
+		if rng.IntN(2) == 0 {
+			slices.Reverse(rows)
+		}
+		if rng.IntN(2) == 0 {
+			rows = transpose(rows)
+		}
+		if reflections(rows, 0) == 1 && reflections(rows, 1) == 1 {
+			return rows
+		}
+	}
+}
+
+func transpose(rows [][]byte) [][]byte {
+	columns := make([][]byte, len(rows[0]))
+	for j := range columns {
+		columns[j] = make([]byte, len(rows))
+		for i := range rows {
+			columns[j][i] = rows[i][j]
+		}
+	}
+	return columns
+}
+
+// reflections counts the lines of both orientations the pattern mirrors at
+// with exactly the given number of smudges.
+func reflections(rows [][]byte, smudges int) (count int) {
+	for _, rows := range [][][]byte{rows, transpose(rows)} {
+		for index := 1; index < len(rows); index++ {
+			differences := 0
+			for i1, i2 := index-1, index; i1 >= 0 && i2 < len(rows); i1, i2 = i1-1, i2+1 {
+				for j := range rows[i1] {
+					if rows[i1][j] != rows[i2][j] {
+						differences++
+					}
+				}
+			}
+			if differences == smudges {
+				count++
+			}
+		}
+	}
+	return
+}
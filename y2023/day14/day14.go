@@ -0,0 +1,194 @@
+package day14
+
+import (
+	"advent/registry"
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"advent/utils/render"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+type Tile rune
+
+const (
+	Round Tile = 'O'
+	Cube  Tile = '#'
+	Empty Tile = '.'
+)
+
+type Platform struct {
+	grid.Grid[Tile]
+}
+
+func (platform Platform) clone() Platform {
+	return Platform{platform.Clone()}
+}
+
+func (platform Platform) tiltNorth() {
+	h, w := platform.Height(), platform.Width()
+	for i1 := 1; i1 < h; i1++ {
+		for j := 0; j < w; j++ {
+			if platform.Grid[i1][j] == Round && platform.Grid[i1-1][j] == Empty {
+				i2 := i1 - 1
+				for i2 > 0 && platform.Grid[i2-1][j] == Empty {
+					i2--
+				}
+				platform.Grid[i1][j], platform.Grid[i2][j] = platform.Grid[i2][j], platform.Grid[i1][j]
+			}
+		}
+	}
+}
+
+func (platform Platform) tiltWest() {
+	h, w := platform.Height(), platform.Width()
+	for j1 := 1; j1 < w; j1++ {
+		for i := 0; i < h; i++ {
+			if platform.Grid[i][j1] == Round && platform.Grid[i][j1-1] == Empty {
+				j2 := j1 - 1
+				for j2 > 0 && platform.Grid[i][j2-1] == Empty {
+					j2--
+				}
+				platform.Grid[i][j1], platform.Grid[i][j2] = platform.Grid[i][j2], platform.Grid[i][j1]
+			}
+		}
+	}
+}
+
+func (platform Platform) tiltSouth() {
+	h, w := platform.Height(), platform.Width()
+	for i1 := h - 2; i1 >= 0; i1-- {
+		for j := 0; j < w; j++ {
+			if platform.Grid[i1][j] == Round && platform.Grid[i1+1][j] == Empty {
+				i2 := i1 + 1
+				for i2 < h-1 && platform.Grid[i2+1][j] == Empty {
+					i2++
+				}
+				platform.Grid[i1][j], platform.Grid[i2][j] = platform.Grid[i2][j], platform.Grid[i1][j]
+			}
+		}
+	}
+}
+
+func (platform Platform) tiltEast() {
+	h, w := platform.Height(), platform.Width()
+	for j1 := w - 2; j1 >= 0; j1-- {
+		for i := 0; i < h; i++ {
+			if platform.Grid[i][j1] == Round && platform.Grid[i][j1+1] == Empty {
+				j2 := j1 + 1
+				for j2 < w-1 && platform.Grid[i][j2+1] == Empty {
+					j2++
+				}
+				platform.Grid[i][j1], platform.Grid[i][j2] = platform.Grid[i][j2], platform.Grid[i][j1]
+			}
+		}
+	}
+}
+
+func (platform Platform) fingerprint() string {
+	var bits big.Int
+	i := 0
+	for _, row := range platform.Grid {
+		for _, tile := range row {
+			if tile == Empty {
+				i++
+			} else if tile == Round {
+				bits.SetBit(&bits, i, 1)
+				i++
+			}
+		}
+	}
+	return bits.String()
+}
+
+func (platform Platform) tiltCounterclockwise(times int) Platform {
+	platform = platform.clone()
+	fingerprintToIndex := make(map[string]int, 0)
+	fingerprintToIndex[platform.fingerprint()] = 0
+	states := make([]Platform, 0)
+	states = append(states, platform.clone())
+	for i := 1; i <= times; i++ {
+		platform.tiltNorth()
+		platform.tiltWest()
+		platform.tiltSouth()
+		platform.tiltEast()
+		fingerprint := platform.fingerprint()
+		if index, found := fingerprintToIndex[fingerprint]; found {
+			loopLen := i - index
+			iterationsLeft := times - i
+			modulo := iterationsLeft % loopLen
+			return states[index+modulo]
+		}
+		fingerprintToIndex[fingerprint] = len(states)
+		states = append(states, platform.clone())
+	}
+	return platform
+}
+
+func (platform Platform) totalLoad() (load int) {
+	for i, factor := platform.Height()-1, 1; i >= 0; i, factor = i-1, factor+1 {
+		for _, tile := range platform.Grid[i] {
+			if tile == Round {
+				load += factor
+			}
+		}
+	}
+	return
+}
+
+func (platform Platform) String() string {
+	return platform.Render(grid.Runes[Tile])
+}
+
+func parseTile(r rune) (Tile, error) {
+	switch tile := Tile(r); tile {
+	case Round, Cube, Empty:
+		return tile, nil
+	default:
+		return 0, fmt.Errorf("unknown tile %q", r)
+	}
+}
+
+type Options struct {
+	Cycles int `opt:"cycles" default:"1000000000" min:"0" usage:"spin cycles to run in part 2"`
+}
+
+type Dish struct {
+	platform Platform
+	opts     Options
+}
+
+func (dish Dish) Part1(ctx context.Context) (int, error) {
+	platform := dish.platform.clone()
+	platform.tiltNorth()
+	return platform.totalLoad(), nil
+}
+
+func (dish Dish) Part2(ctx context.Context) (int, error) {
+	return dish.platform.tiltCounterclockwise(dish.opts.Cycles).totalLoad(), nil
+}
+
+// Draw pictures the platform after the spin cycles of part 2.
+func (dish Dish) Draw() render.Picture {
+	return render.Draw(dish.platform.tiltCounterclockwise(dish.opts.Cycles).Grid, func(_ geom.Point, tile Tile) render.Cell {
+		cell := render.Cell{Rune: rune(tile)}
+		if tile == Round {
+			cell.Color = render.Gold
+		}
+		return cell
+	})
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 14, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	tiles, err := grid.Parse(r, parseTile)
+	if err != nil {
+		return nil, err
+	}
+	return Dish{Platform{tiles}, opts}, nil
+}
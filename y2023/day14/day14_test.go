@@ -0,0 +1,28 @@
+package day14
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 14,
+		golden.Case{Input: "day14_test.txt", Part1: 136, Part2: 64},
+		golden.Case{Input: "day14_test.txt", Options: registry.Options{"cycles": "0"}, Part2: 104},
+		golden.Case{Input: "day14_test.txt", Options: registry.Options{"cycles": "1"}, Part2: 87},
+		golden.Case{Input: "day14_test.txt", Options: registry.Options{"cycles": "3"}, Part2: 69},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 14)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 14)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 14)
+}
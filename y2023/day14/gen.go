@@ -0,0 +1,29 @@
+package day14
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 14, Generate)
+}
+
+// Generate writes a square platform of round and cube rocks.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	side := size.Pick(10, 50, 100)
+	for range side {
+		for range side {
+			switch n := rng.IntN(20); {
+			case n < 4:
+				w.WriteByte('O')
+			case n < 7:
+				w.WriteByte('#')
+			default:
+				w.WriteByte('.')
+			}
+		}
+		w.WriteByte('\n')
+	}
+}
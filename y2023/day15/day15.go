@@ -1,8 +1,12 @@
 package day15
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"advent/utils/logger"
+	"context"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 )
@@ -110,7 +114,7 @@ func sumHash(sum int, steps []Key) int {
 var putRe = regexp.MustCompile(`^(\w+)=(\d)$`)
 var deleteRe = regexp.MustCompile(`^(\w+)-$`)
 
-func parseOperations(line string) []Operation {
+func parseOperations(line string) ([]Operation, error) {
 	fields := utils.Fields(line, ",")
 	operations := make([]Operation, len(fields))
 	for i, field := range fields {
@@ -121,10 +125,10 @@ func parseOperations(line string) []Operation {
 		} else if match := deleteRe.FindStringSubmatch(field); match != nil {
 			operations[i] = Key(match[1])
 		} else {
-			panic("Unknown operation")
+			return nil, fmt.Errorf("unknown operation %q", field)
 		}
 	}
-	return operations
+	return operations, nil
 }
 
 func applyOperations(hashMap *HashMap, operations []Operation) *HashMap {
@@ -134,19 +138,41 @@ func applyOperations(hashMap *HashMap, operations []Operation) *HashMap {
 	return hashMap
 }
 
-func Run() int {
-	// return utils.ProcessInput("day15.txt", 0, parseKeys, sumHash)
-	hashMap := utils.ProcessInput("day15.txt", &HashMap{}, parseOperations, applyOperations)
+type Sequence struct {
+	keys       [][]Key
+	operations [][]Operation
+}
+
+func (sequence Sequence) Part1(ctx context.Context) (int, error) {
+	return utils.Fold(sequence.keys, 0, sumHash), nil
+}
 
-	// hashMap := &HashMap{}
-	// var line string
-	// for n, _ := fmt.Scanln(&line); n > 0; n, _ = fmt.Scanln(&line) {
-	// 	for _, op := range parseOperations(line) {
-	// 		op.applyTo(hashMap)
-	// 		fmt.Println(hashMap)
-	// 	}
-	// }
+func (sequence Sequence) Part2(ctx context.Context) (int, error) {
+	hashMap := utils.Fold(sequence.operations, &HashMap{}, applyOperations)
+	logger.From(ctx).Debug("boxes", "hashmap", logger.Lazy(hashMap.String))
+	return hashMap.totalFocusingPower(), nil
+}
+
+func parseSequence(line string) (sequence Sequence, err error) {
+	operations, err := parseOperations(line)
+	if err != nil {
+		return
+	}
+	sequence.keys = [][]Key{parseKeys(line)}
+	sequence.operations = [][]Operation{operations}
+	return
+}
+
+func appendSequence(acc Sequence, sequence Sequence) Sequence {
+	acc.keys = append(acc.keys, sequence.keys...)
+	acc.operations = append(acc.operations, sequence.operations...)
+	return acc
+}
+
+func init() {
+	registry.Register(2023, 15, Parse)
+}
 
-	fmt.Println(hashMap)
-	return hashMap.totalFocusingPower()
+func Parse(r io.Reader) (registry.Solver, error) {
+	return utils.ProcessReader(r, Sequence{}, parseSequence, appendSequence)
 }
@@ -0,0 +1,24 @@
+package day15
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 15,
+		golden.Case{Input: "day15_test.txt", Part1: 1320, Part2: 145},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 15)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 15)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 15)
+}
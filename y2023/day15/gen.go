@@ -0,0 +1,34 @@
+package day15
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+	"strconv"
+)
+
+func init() {
+	gen.Register(2023, 15, Generate)
+}
+
+// Generate writes one line of steps that put and remove lenses with labels
+// from a small pool, so that labels meet in the boxes again.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	labels := size.Pick(5, 50, 500)
+	pool := make([]string, 0, labels)
+	for length := 2; len(pool) < labels; length++ {
+		pool = append(pool, gen.Names(rng, min(labels-len(pool), labels/3+1), length, pool...)...)
+	}
+	for i := range size.Pick(11, 500, 4000) {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(pool[rng.IntN(len(pool))])
+		if rng.IntN(5) < 3 {
+			w.WriteString("=" + strconv.Itoa(gen.Between(rng, 1, 9)))
+		} else {
+			w.WriteByte('-')
+		}
+	}
+	w.WriteByte('\n')
+}
@@ -0,0 +1,187 @@
+package day16
+
+import (
+	"advent/registry"
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"advent/utils/logger"
+	"advent/utils/render"
+	"context"
+	"fmt"
+	"io"
+)
+
+type Tile rune
+
+const (
+	Empty       Tile = '.'
+	MirrirSlash Tile = '/'
+	MirrorBack  Tile = '\\'
+	SplitHor    Tile = '-'
+	SplitVer    Tile = '|'
+)
+
+type Energized [4]bool
+
+type Game struct {
+	field       grid.Grid[Tile]
+	isEnergized grid.Grid[Energized]
+}
+
+func (game Game) h() int {
+	return game.field.Height()
+}
+
+func (game Game) w() int {
+	return game.field.Width()
+}
+
+func (game Game) picture() render.Picture {
+	return render.Draw(game.field, func(p geom.Point, tile Tile) render.Cell {
+		cell := render.Cell{Rune: rune(tile)}
+		if game.isEnergized.At(p) != (Energized{}) {
+			cell.Color = render.Green
+		}
+		return cell
+	})
+}
+
+// energizedTiles marks the energized tiles with # for plain text logs.
+func (game Game) energizedTiles() string {
+	return game.field.Render(func(p geom.Point, tile Tile) string {
+		if game.isEnergized.At(p) != (Energized{}) {
+			return "#"
+		}
+		return string(tile)
+	})
+}
+
+func (game Game) String() string {
+	return render.ANSIString(game.picture())
+}
+
+// Draw pictures the tiles energized by the beam of part 1.
+func (game Game) Draw() render.Picture {
+	game.beam(geom.Point{}, geom.Right)
+	defer game.clear()
+	return game.picture()
+}
+
+func (game Game) beam(p geom.Point, dir geom.Dir) {
+	if !game.field.InBounds(p) {
+		return
+	}
+	if game.isEnergized[p.I][p.J][dir] {
+		return
+	}
+	game.isEnergized[p.I][p.J][dir] = true
+	switch game.field.At(p) {
+	case Empty:
+		game.beam(p.Step(dir), dir)
+	case MirrirSlash:
+		if dir.Vertical() {
+			dir = dir.TurnRight()
+		} else {
+			dir = dir.TurnLeft()
+		}
+		game.beam(p.Step(dir), dir)
+	case MirrorBack:
+		if dir.Vertical() {
+			dir = dir.TurnLeft()
+		} else {
+			dir = dir.TurnRight()
+		}
+		game.beam(p.Step(dir), dir)
+	case SplitHor:
+		if dir.Vertical() {
+			game.beam(p.Step(geom.Left), geom.Left)
+			game.beam(p.Step(geom.Right), geom.Right)
+		} else {
+			game.beam(p.Step(dir), dir)
+		}
+	case SplitVer:
+		if dir.Vertical() {
+			game.beam(p.Step(dir), dir)
+		} else {
+			game.beam(p.Step(geom.Up), geom.Up)
+			game.beam(p.Step(geom.Down), geom.Down)
+		}
+	}
+}
+
+func (game Game) clear() {
+	for _, row := range game.isEnergized {
+		for j := range row {
+			row[j] = [4]bool{}
+		}
+	}
+}
+
+func (game Game) countEnergized() (count int) {
+	for _, row := range game.isEnergized {
+		for _, energized := range row {
+			for _, dirEnergized := range energized {
+				if dirEnergized {
+					count++
+					break
+				}
+			}
+		}
+	}
+	return
+}
+
+func (game Game) maxCountEnergized(ctx context.Context) (maxCount int) {
+	log := logger.From(ctx)
+	checkForBeam := func(i, j int, dir geom.Dir) {
+		game.beam(geom.Point{I: i, J: j}, dir)
+		count := game.countEnergized()
+		if count > maxCount {
+			log.Debug("new record", "i", i, "j", j, "dir", dir, "count", count)
+			logger.Trace(ctx, "energized", "tiles", logger.Lazy(game.energizedTiles))
+			maxCount = count
+		}
+		game.clear()
+	}
+	for i := 0; i < game.h(); i++ {
+		checkForBeam(i, 0, geom.Right)
+		checkForBeam(i, game.w()-1, geom.Left)
+	}
+	for j := 0; j < game.w(); j++ {
+		checkForBeam(0, j, geom.Down)
+		checkForBeam(game.h()-1, j, geom.Up)
+	}
+	return maxCount
+}
+
+func parseTile(r rune) (Tile, error) {
+	switch tile := Tile(r); tile {
+	case Empty, MirrirSlash, MirrorBack, SplitHor, SplitVer:
+		return tile, nil
+	default:
+		return 0, fmt.Errorf("unknown tile %q", r)
+	}
+}
+
+func (game Game) Part1(ctx context.Context) (count int, err error) {
+	game.beam(geom.Point{}, geom.Right)
+	count = game.countEnergized()
+	game.clear()
+	return
+}
+
+func (game Game) Part2(ctx context.Context) (int, error) {
+	return game.maxCountEnergized(ctx), nil
+}
+
+func init() {
+	registry.Register(2023, 16, Parse)
+}
+
+func Parse(r io.Reader) (registry.Solver, error) {
+	field, err := grid.Parse(r, parseTile)
+	if err != nil {
+		return nil, err
+	}
+	return Game{field, grid.New(field.Height(), field.Width(), Energized{})}, nil
+}
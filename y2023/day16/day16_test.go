@@ -0,0 +1,24 @@
+package day16
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 16,
+		golden.Case{Input: "day16_test.txt", Part1: 46, Part2: 51},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 16)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 16)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 16)
+}
@@ -0,0 +1,29 @@
+package day16
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+)
+
+const devices = `/\-|`
+
+func init() {
+	gen.Register(2023, 16, Generate)
+}
+
+// Generate writes a square contraption with mirrors and splitters on about
+// one tile in eight.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	side := size.Pick(10, 50, 110)
+	for range side {
+		for range side {
+			if rng.IntN(8) == 0 {
+				w.WriteByte(devices[rng.IntN(len(devices))])
+			} else {
+				w.WriteByte('.')
+			}
+		}
+		w.WriteByte('\n')
+	}
+}
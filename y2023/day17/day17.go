@@ -0,0 +1,207 @@
+package day17
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"advent/utils/logger"
+	"advent/utils/render"
+	"container/heap"
+	"context"
+	"fmt"
+	"io"
+)
+
+type Crucible struct {
+	minDirSteps int
+	maxDirSteps int
+}
+
+var (
+	Regular = Crucible{minDirSteps: 1, maxDirSteps: 3}
+	Ultra   = Crucible{minDirSteps: 4, maxDirSteps: 10}
+)
+
+type Step struct {
+	prev      *Step
+	from      geom.Point
+	to        geom.Point
+	dir       geom.Dir
+	dirCount  int
+	totalLoss int
+}
+
+func (step *Step) String() string {
+	if step.prev == nil {
+		return fmt.Sprintf("%c (%d): %d", step.dir.Arrow(), step.dirCount, step.totalLoss)
+	}
+	return fmt.Sprintf("[%d %d] %c (%d): %d", step.from.I, step.from.J, step.dir.Arrow(), step.dirCount, step.totalLoss)
+}
+
+type Field struct {
+	grid.Grid[int]
+}
+
+func (field Field) nextSteps(step *Step, crucible Crucible) (results []*Step) {
+	results = make([]*Step, 0, 3)
+	for d := -1; d <= 1; d++ {
+		next := &Step{prev: step, from: step.to}
+		if d == 0 {
+			if step.dirCount >= crucible.maxDirSteps {
+				continue
+			}
+			next.dirCount = step.dirCount + 1
+		} else {
+			if step.dirCount < crucible.minDirSteps {
+				continue
+			}
+			next.dirCount = 1
+		}
+
+		next.dir = step.dir.Turn(d)
+		next.to = next.from.Step(next.dir)
+		loss, found := field.Get(next.to)
+		if !found {
+			continue
+		}
+		next.totalLoss = step.totalLoss + loss
+		results = append(results, next)
+	}
+	return
+}
+
+func (field Field) picture(path *Step) render.Picture {
+	dirs := make(map[geom.Point]geom.Dir)
+	for ; path != nil; path = path.prev {
+		dirs[path.to] = path.dir
+	}
+	return render.Draw(field.Grid, func(p geom.Point, loss int) render.Cell {
+		if dir, found := dirs[p]; found {
+			return render.Cell{Rune: dir.Arrow(), Color: render.Red}
+		}
+		return render.Cell{Rune: rune('0' + loss)}
+	})
+}
+
+type PathKey struct {
+	pos      geom.Point
+	dir      geom.Dir
+	dirCount int
+}
+
+func (step *Step) toKey() PathKey {
+	return PathKey{
+		pos:      step.to,
+		dir:      step.dir,
+		dirCount: step.dirCount,
+	}
+}
+
+func (field Field) calculateBestPath(crucible Crucible) (*Step, error) {
+	if crucible.minDirSteps > crucible.maxDirSteps {
+		return nil, fmt.Errorf("crucible must move %d blocks before turning but can only move %d", crucible.minDirSteps, crucible.maxDirSteps)
+	}
+	minPaths := make(map[PathKey]*Step)
+	steps := &StepHeap{
+		&Step{dir: geom.Right, dirCount: crucible.maxDirSteps},
+		&Step{dir: geom.Down, dirCount: crucible.maxDirSteps},
+	}
+	target := geom.Point{I: field.Height() - 1, J: field.Width() - 1}
+	heap.Init(steps)
+	for steps.Len() > 0 {
+		step := heap.Pop(steps).(*Step)
+		if step.to == target {
+			if step.dirCount >= crucible.minDirSteps {
+				return step, nil
+			}
+		}
+		if minPath := minPaths[step.toKey()]; minPath != nil && minPath.totalLoss < step.totalLoss {
+			continue
+		}
+		for _, next := range field.nextSteps(step, crucible) {
+			if minPath := minPaths[next.toKey()]; minPath != nil && minPath.totalLoss <= next.totalLoss {
+				continue
+			}
+			minPaths[next.toKey()] = next
+			heap.Push(steps, next)
+		}
+	}
+	return nil, fmt.Errorf("crucible cannot reach the factory")
+}
+
+type StepHeap []*Step
+
+func (h StepHeap) Len() int           { return len(h) }
+func (h StepHeap) Less(i, j int) bool { return h[i].totalLoss < h[j].totalLoss }
+func (h StepHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *StepHeap) Push(x interface{}) {
+	*h = append(*h, x.(*Step))
+}
+func (h *StepHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+func parseLoss(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, fmt.Errorf("heat loss %q is not a digit", r)
+	}
+	return int(r - '0'), nil
+}
+
+type Options struct {
+	MinSteps *int `opt:"min-steps" min:"1" usage:"blocks to move before turning (default: 1 for part 1, 4 for part 2)"`
+	MaxSteps *int `opt:"max-steps" min:"1" usage:"blocks to move before having to turn (default: 3 for part 1, 10 for part 2)"`
+}
+
+func (opts Options) crucible(fallback Crucible) Crucible {
+	return Crucible{
+		minDirSteps: utils.Or(opts.MinSteps, fallback.minDirSteps),
+		maxDirSteps: utils.Or(opts.MaxSteps, fallback.maxDirSteps),
+	}
+}
+
+type City struct {
+	field Field
+	opts  Options
+}
+
+func (city City) Part1(ctx context.Context) (int, error) {
+	path, err := city.field.calculateBestPath(city.opts.crucible(Regular))
+	if err != nil {
+		return 0, err
+	}
+	return path.totalLoss, nil
+}
+
+func (city City) Part2(ctx context.Context) (int, error) {
+	path, err := city.field.calculateBestPath(city.opts.crucible(Ultra))
+	if err != nil {
+		return 0, err
+	}
+	logger.Trace(ctx, "best path", "loss", path.totalLoss, "field", logger.Lazy(city.field.picture(path).String))
+	return path.totalLoss, nil
+}
+
+// Draw pictures the best path of the ultra crucible, or just the city when
+// there is none.
+func (city City) Draw() render.Picture {
+	path, _ := city.field.calculateBestPath(city.opts.crucible(Ultra))
+	return city.field.picture(path)
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 17, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	field, err := grid.Parse(r, parseLoss)
+	if err != nil {
+		return nil, err
+	}
+	return City{Field{field}, opts}, nil
+}
@@ -0,0 +1,29 @@
+package day17
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 17,
+		golden.Case{Input: "day17_test.txt", Part1: 102, Part2: 94},
+		golden.Case{Input: "day17_test.txt", Options: registry.Options{"min-steps": "4", "max-steps": "10"}, Part1: 94},
+		golden.Case{Input: "day17_test.txt", Options: registry.Options{"min-steps": "1", "max-steps": "3"}, Part2: 102},
+		golden.Case{Input: "day17_test.txt", Options: registry.Options{"min-steps": "5", "max-steps": "2"}, Err: "crucible must move 5 blocks before turning but can only move 2"},
+		golden.Case{Input: "day17_test.txt", Options: registry.Options{"max-steps": "0"}, Err: "option max-steps: 0 is less than 1"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 17)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 17)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 17)
+}
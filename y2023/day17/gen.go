@@ -0,0 +1,22 @@
+package day17
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 17, Generate)
+}
+
+// Generate writes a square map of heat losses from 1 to 9.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	side := size.Pick(13, 60, 141)
+	for range side {
+		for range side {
+			w.WriteByte(byte('1' + rng.IntN(9)))
+		}
+		w.WriteByte('\n')
+	}
+}
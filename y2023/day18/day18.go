@@ -1,38 +1,20 @@
 package day18
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"advent/utils/geom"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"sort"
 	"strconv"
 )
 
-type Direction rune
-
-const (
-	Up    Direction = 'U'
-	Left  Direction = 'L'
-	Right Direction = 'R'
-	Down  Direction = 'D'
-)
-
-func (dir Direction) offset(i, j, len int) (int, int) {
-	switch dir {
-	case Right:
-		return i, j + len
-	case Down:
-		return i + len, j
-	case Left:
-		return i, j - len
-	case Up:
-		return i - len, j
-	}
-	return i, j
-}
-
 type Trench struct {
-	dir Direction
+	dir geom.Dir
 	len int
 }
 
@@ -118,22 +100,22 @@ type Plan []Section
 func (lagoon Lagoon) plan() (plan Plan) {
 	plan = nil
 	sections := make(map[int]*Section)
-	i, j := 0, 0
+	var p geom.Point
 	for _, trench := range lagoon {
-		i1, j1 := trench.dir.offset(i, j, trench.len)
-		if i == i1 {
-			border := Cut{j, j1}
-			if j1 < j {
+		next := p.Move(trench.dir, trench.len)
+		if !trench.dir.Vertical() {
+			border := Cut{p.J, next.J}
+			if next.J < p.J {
 				border.j1, border.j2 = border.j2, border.j1
 			}
-			section := sections[i]
+			section := sections[p.I]
 			if section == nil {
-				section = &Section{i: i}
-				sections[i] = section
+				section = &Section{i: p.I}
+				sections[p.I] = section
 			}
 			section.cuts = append(section.cuts, border)
 		}
-		i, j = i1, j1
+		p = next
 	}
 	for _, section := range sections {
 		plan = append(plan, *section)
@@ -160,41 +142,63 @@ func (plan Plan) countFilled() (count int) {
 
 var trenchRe = regexp.MustCompile(`^([ULRD]) (\d+) \(#([0-9a-f]{6})\)$`)
 
-func parseTrench(line string) (trench Trench) {
+var errTrenchFormat = errors.New("expected direction, length and color")
+
+func parseTrench(line string) (trench Trench, err error) {
 	match := trenchRe.FindStringSubmatch(line)
 	if match == nil {
-		panic("regex does not match")
+		return trench, errTrenchFormat
+	}
+	if trench.dir, err = geom.ParseDir(rune(match[1][0])); err != nil {
+		return
 	}
-	trench.dir = Direction(match[1][0])
-	trench.len, _ = strconv.Atoi(match[2])
+	trench.len, err = strconv.Atoi(match[2])
 	return
 }
 
-func parseTrenchFixed(line string) (trench Trench) {
+func parseTrenchFixed(line string) (trench Trench, err error) {
 	match := trenchRe.FindStringSubmatch(line)
 	if match == nil {
-		panic("regex does not match")
+		return trench, errTrenchFormat
 	}
-	dir := match[3][5]
-	switch dir {
-	case '0':
-		trench.dir = 'R'
-	case '1':
-		trench.dir = 'D'
-	case '2':
-		trench.dir = 'L'
-	case '3':
-		trench.dir = 'U'
+	if trench.dir, err = geom.ParseDir(rune(match[3][5])); err != nil {
+		return
+	}
+	_, err = fmt.Sscanf(match[3][0:5], "%05x", &trench.len)
+	return
+}
+
+type DigPlan struct {
+	lagoon Lagoon
+	fixed  Lagoon
+}
+
+func (digPlan DigPlan) Part1(ctx context.Context) (int, error) {
+	return digPlan.lagoon.plan().countFilled(), nil
+}
+
+func (digPlan DigPlan) Part2(ctx context.Context) (int, error) {
+	return digPlan.fixed.plan().countFilled(), nil
+}
+
+func parseTrenches(line string) (trenches [2]Trench, err error) {
+	if trenches[0], err = parseTrench(line); err != nil {
+		return
 	}
-	_, _ = fmt.Sscanf(match[3][0:5], "%05x", &trench.len)
+	trenches[1], err = parseTrenchFixed(line)
 	return
 }
 
-func appendRows(lagoon Lagoon, trench Trench) Lagoon {
-	return append(lagoon, trench)
+func appendRows(digPlan DigPlan, trenches [2]Trench) DigPlan {
+	digPlan.lagoon = append(digPlan.lagoon, trenches[0])
+	digPlan.fixed = append(digPlan.fixed, trenches[1])
+	return digPlan
+}
+
+func init() {
+	registry.Register(2023, 18, Parse)
 }
 
-func Run() int {
-	lagoon := utils.ProcessInput("day18.txt", Lagoon{}, parseTrenchFixed, appendRows)
-	return lagoon.plan().countFilled()
+func Parse(r io.Reader) (registry.Solver, error) {
+	return utils.ProcessReader(r, DigPlan{}, parseTrenches, appendRows)
 }
@@ -0,0 +1,28 @@
+package day18
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 18,
+		golden.Case{Input: "day18_test.txt", Part1: 62, Part2: 952408144115},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 18)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 18)
+}
+
+func FuzzParseTrenches(f *testing.F) {
+	golden.FuzzLines(f, 2023, 18, parseTrenches)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 18)
+}
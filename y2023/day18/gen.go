@@ -0,0 +1,91 @@
+package day18
+
+import (
+	"advent/gen"
+	"advent/utils/geom"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 18, Generate)
+}
+
+// run is a straight part of the loop, crossing steps rows or columns of
+// the coarse grid the loop is drawn on.
+type run struct {
+	dir   geom.Dir
+	from  geom.Point
+	steps int
+}
+
+// Generate draws a loop on a coarse grid and spaces out its rows and
+// columns by random gaps, which keeps the lagoon from crossing itself. The
+// colors dig the same loop turned or mirrored, with gaps of up to a million.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	side := size.Pick(6, 12, 25)
+	runs := runs(gen.Loop(rng, side, side))
+	lengths := spaced(rng, runs, side, size.Pick(3, 6, 10))
+	fixed := spaced(rng, runs, side, 1_000_000/side)
+	turn := rng.IntN(4)
+	mirror := rng.IntN(2) == 0
+	for i, r := range runs {
+		dir := r.dir.Turn(turn)
+		if mirror && !dir.Vertical() {
+			dir = dir.Reverse()
+		}
+		fmt.Fprintf(w, "%v %d (#%05x%d)\n", r.dir, lengths[i], fixed[i], dir)
+	}
+}
+
+// runs splits the loop into straight runs, starting at a corner.
+func runs(loop []geom.Point) (runs []run) {
+	dir := func(k int) geom.Dir {
+		from, to := loop[k%len(loop)], loop[(k+1)%len(loop)]
+		for _, dir := range geom.Dirs {
+			if from.Step(dir) == to {
+				return dir
+			}
+		}
+		panic("cells are not adjacent")
+	}
+	start := 0
+	for dir(start) == dir(start+len(loop)-1) {
+		start++
+	}
+	for k := start; k < start+len(loop); k++ {
+		if len(runs) > 0 && runs[len(runs)-1].dir == dir(k) {
+			runs[len(runs)-1].steps++
+		} else {
+			runs = append(runs, run{dir(k), loop[k%len(loop)], 1})
+		}
+	}
+	return
+}
+
+// spaced returns the length of every run when the coarse rows and columns
+// are up to gap apart.
+func spaced(rng *rand.Rand, runs []run, side, gap int) []int {
+	rows, columns := make([]int, side), make([]int, side)
+	for i := range side {
+		rows[i], columns[i] = gen.Between(rng, 1, gap), gen.Between(rng, 1, gap)
+	}
+	lengths := make([]int, len(runs))
+	for i, r := range runs {
+		for k := range r.steps {
+			p := r.from.Move(r.dir, k)
+			switch r.dir {
+			case geom.Down:
+				lengths[i] += rows[p.I]
+			case geom.Up:
+				lengths[i] += rows[p.I-1]
+			case geom.Right:
+				lengths[i] += columns[p.J]
+			case geom.Left:
+				lengths[i] += columns[p.J-1]
+			}
+		}
+	}
+	return lengths
+}
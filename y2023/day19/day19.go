@@ -1,7 +1,12 @@
 package day19
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 )
@@ -104,22 +109,20 @@ func (parts PartRange) size() (product int) {
 	return
 }
 
-type WorkflowOrPart struct {
-	workflow *Workflow
-	part     Part
-}
-
-func (system System) isAccepted(part Part) bool {
+func (system System) isAccepted(ctx context.Context, part Part) (bool, error) {
 	workflow := system.workflows["in"]
 OUTER:
 	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
 		for _, rule := range workflow.rules {
 			if rule.condition.matches(part) {
 				switch rule.outcome {
 				case "A":
-					return true
+					return true, nil
 				case "R":
-					return false
+					return false, nil
 				default:
 					workflow = system.workflows[rule.outcome]
 					continue OUTER
@@ -130,9 +133,13 @@ OUTER:
 	}
 }
 
-func (system System) sumOfApprovedPartsCategories() (sum int) {
+func (system System) sumOfApprovedPartsCategories(ctx context.Context) (sum int, err error) {
 	for _, part := range system.parts {
-		if system.isAccepted(part) {
+		accepted, err := system.isAccepted(ctx, part)
+		if err != nil {
+			return 0, err
+		}
+		if accepted {
 			for _, value := range part {
 				sum += value
 			}
@@ -141,7 +148,7 @@ func (system System) sumOfApprovedPartsCategories() (sum int) {
 	return
 }
 
-func (system System) numberOfAcceptedCombinations() (total int) {
+func (system System) numberOfAcceptedCombinations(ctx context.Context) (total int, err error) {
 	subsets := []Subset{{
 		workflowName: "in",
 		ruleIdx:      0,
@@ -153,6 +160,9 @@ func (system System) numberOfAcceptedCombinations() (total int) {
 		},
 	}}
 	for len(subsets) > 0 {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
 		next := make([]Subset, 0)
 		for _, subset := range subsets {
 			workflow := system.workflows[subset.workflowName]
@@ -185,10 +195,11 @@ func (system System) numberOfAcceptedCombinations() (total int) {
 	return
 }
 
-var workflowOrPartRe = regexp.MustCompile(`^(\w*)\{(.+)\}$`)
+var workflowRe = regexp.MustCompile(`^(\w+)\{(.+)\}$`)
+var partRe = regexp.MustCompile(`^\{(.+)\}$`)
 var compareRe = regexp.MustCompile(`^(\w+)([<>])(\d+)$`)
 
-func parseWorkflow(name string, rulesStr string) (workflow *Workflow) {
+func parseWorkflow(name string, rulesStr string) (workflow *Workflow, err error) {
 	workflow = &Workflow{name: name}
 	for _, ruleStr := range utils.Fields(rulesStr, ",") {
 		condOut := utils.Fields(ruleStr, ":")
@@ -196,50 +207,127 @@ func parseWorkflow(name string, rulesStr string) (workflow *Workflow) {
 			workflow.rules = append(workflow.rules, Rule{Always(true), condOut[0]})
 			continue
 		}
+		if len(condOut) != 2 {
+			return nil, fmt.Errorf("bad rule %q", ruleStr)
+		}
 		match := compareRe.FindStringSubmatch(condOut[0])
-		value, _ := strconv.Atoi(match[3])
+		if match == nil {
+			return nil, fmt.Errorf("bad condition %q", condOut[0])
+		}
+		value, err := strconv.Atoi(match[3])
+		if err != nil {
+			return nil, err
+		}
 		workflow.rules = append(workflow.rules, Rule{Compare{match[1], match[2], value}, condOut[1]})
 	}
+	if len(workflow.rules) == 0 {
+		return nil, fmt.Errorf("workflow %s has no rules", name)
+	}
 	return
 }
 
-func parsePart(categoriesStr string) (part Part) {
+func parsePart(categoriesStr string) (part Part, err error) {
 	part = make(Part)
 	for _, categoryStr := range utils.Fields(categoriesStr, ",") {
 		keyValue := utils.Fields(categoryStr, "=")
-		value, _ := strconv.Atoi(keyValue[1])
-		part[keyValue[0]] = value
+		if len(keyValue) != 2 {
+			return nil, fmt.Errorf("bad category %q", categoryStr)
+		}
+		if part[keyValue[0]], err = strconv.Atoi(keyValue[1]); err != nil {
+			return nil, err
+		}
 	}
 	return
 }
 
-func parseLine(line string) (wop WorkflowOrPart) {
-	if line == "" {
-		return
+func parseWorkflowLine(line string) (*Workflow, error) {
+	match := workflowRe.FindStringSubmatch(line)
+	if match == nil {
+		return nil, errors.New("expected a workflow")
 	}
-	match := workflowOrPartRe.FindStringSubmatch(line)
-	if match[1] != "" {
-		wop.workflow = parseWorkflow(match[1], match[2])
-	} else {
-		wop.part = parsePart(match[2])
+	return parseWorkflow(match[1], match[2])
+}
+
+func parsePartLine(line string) (Part, error) {
+	match := partRe.FindStringSubmatch(line)
+	if match == nil {
+		return nil, errors.New("expected a part")
 	}
-	return
+	return parsePart(match[1])
+}
+
+func addWorkflow(system System, workflow *Workflow) System {
+	system.workflows[workflow.name] = *workflow
+	return system
+}
+
+func appendPart(system System, part Part) System {
+	system.parts = append(system.parts, part)
+	return system
 }
 
-func aggregate(system System, wop WorkflowOrPart) System {
-	if wop.workflow != nil {
-		if system.workflows == nil {
-			system.workflows = make(map[string]Workflow)
+func (system System) Part1(ctx context.Context) (int, error) {
+	return system.sumOfApprovedPartsCategories(ctx)
+}
+
+func (system System) Part2(ctx context.Context) (int, error) {
+	return system.numberOfAcceptedCombinations(ctx)
+}
+
+func init() {
+	registry.Register(2023, 19, Parse)
+}
+
+func (system System) validate() error {
+	if _, found := system.workflows["in"]; !found {
+		return errors.New("no workflow named in")
+	}
+	for name, workflow := range system.workflows {
+		if _, always := workflow.rules[len(workflow.rules)-1].condition.(Always); !always {
+			return fmt.Errorf("workflow %s does not end with a fallback rule", name)
+		}
+		for _, rule := range workflow.rules {
+			if _, found := system.workflows[rule.outcome]; !found && rule.outcome != "A" && rule.outcome != "R" {
+				return fmt.Errorf("workflow %s sends parts to unknown workflow %s", name, rule.outcome)
+			}
 		}
-		system.workflows[wop.workflow.name] = *wop.workflow
 	}
-	if wop.part != nil {
-		system.parts = append(system.parts, wop.part)
+	return system.checkLoops("in", make(map[string]bool))
+}
+
+// checkLoops makes sure no part can be sent around the workflows forever.
+// Open workflows are on the current path and map to true, finished ones
+// map to false.
+func (system System) checkLoops(name string, open map[string]bool) error {
+	if name == "A" || name == "R" {
+		return nil
 	}
-	return system
+	if stillOpen, seen := open[name]; seen {
+		if stillOpen {
+			return fmt.Errorf("workflow %s sends parts back to itself", name)
+		}
+		return nil
+	}
+	open[name] = true
+	for _, rule := range system.workflows[name].rules {
+		if err := system.checkLoops(rule.outcome, open); err != nil {
+			return err
+		}
+	}
+	open[name] = false
+	return nil
 }
 
-func Run() int {
-	system := utils.ProcessInput("day19.txt", System{}, parseLine, aggregate)
-	return system.numberOfAcceptedCombinations()
+func Parse(r io.Reader) (registry.Solver, error) {
+	system, err := utils.ProcessBlocks(r, System{workflows: make(map[string]Workflow)},
+		utils.LinesSection(parseWorkflowLine, addWorkflow),
+		utils.LinesSection(parsePartLine, appendPart),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := system.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", utils.InputName(r), err)
+	}
+	return system, nil
 }
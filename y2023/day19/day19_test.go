@@ -0,0 +1,33 @@
+package day19
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 19,
+		golden.Case{Input: "day19_test.txt", Part1: 19114, Part2: 167409079868000},
+		golden.Case{Input: "day19_test2.txt", Err: "workflow in sends parts back to itself"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 19)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 19)
+}
+
+func FuzzParseWorkflow(f *testing.F) {
+	golden.FuzzLines(f, 2023, 19, parseWorkflowLine)
+}
+
+func FuzzParsePart(f *testing.F) {
+	golden.FuzzLines(f, 2023, 19, parsePartLine)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 19)
+}
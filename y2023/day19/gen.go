@@ -0,0 +1,46 @@
+package day19
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+func init() {
+	gen.Register(2023, 19, Generate)
+}
+
+// Generate writes a tree of workflows growing from in, so that every part
+// ends up accepted or rejected, followed by parts with ratings up to 4000.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	names := gen.Names(rng, size.Pick(8, 60, 550), 3, "in")
+	queue := []string{"in"}
+	target := func() string {
+		if len(names) > 0 && rng.IntN(3) > 0 {
+			name := names[0]
+			names = names[1:]
+			queue = append(queue, name)
+			return name
+		}
+		return []string{"A", "R"}[rng.IntN(2)]
+	}
+	var workflows []string
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		rules := make([]string, gen.Between(rng, 1, 3))
+		for i := range rules {
+			rules[i] = fmt.Sprintf("%c%c%d:%s", "xmas"[rng.IntN(4)], "<>"[rng.IntN(2)], gen.Between(rng, 1, 4000), target())
+		}
+		workflows = append(workflows, fmt.Sprintf("%s{%s,%s}", name, strings.Join(rules, ","), target()))
+	}
+	rng.Shuffle(len(workflows), func(i, j int) {
+		workflows[i], workflows[j] = workflows[j], workflows[i]
+	})
+	w.WriteString(strings.Join(workflows, "\n") + "\n\n")
+	for range size.Pick(5, 50, 200) {
+		fmt.Fprintf(w, "{x=%d,m=%d,a=%d,s=%d}\n", gen.Between(rng, 1, 4000), gen.Between(rng, 1, 4000), gen.Between(rng, 1, 4000), gen.Between(rng, 1, 4000))
+	}
+}
@@ -0,0 +1,349 @@
+package day20
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/graph"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+type Frequency int
+
+const (
+	None Frequency = -1
+	Low  Frequency = 0
+	High Frequency = 1
+)
+
+type Pulse struct {
+	src  string
+	dst  string
+	freq Frequency
+}
+
+func (pulse Pulse) String() string {
+	return fmt.Sprintf("%v -%v-> %v", pulse.src, pulse.freq, pulse.dst)
+}
+
+type Module interface {
+	name() string
+	dsts() []string
+	connect(src string)
+	apply(in Pulse) Frequency
+	reset()
+	attrs() graph.Attrs
+}
+
+type Modules map[string]Module
+
+func (modules Modules) pushButton(observe func(Pulse)) (lowNum int, highNum int) {
+	queue := make([]Pulse, 0)
+	queue = append(queue, Pulse{"button", "broadcaster", Low})
+	for len(queue) > 0 {
+		pulse := queue[0]
+		if observe != nil {
+			observe(pulse)
+		}
+		queue = queue[1:]
+		switch pulse.freq {
+		case Low:
+			lowNum++
+		case High:
+			highNum++
+		}
+		module := modules[pulse.dst]
+		outFreq := module.apply(pulse)
+		if outFreq == None {
+			continue
+		}
+		for _, dst := range module.dsts() {
+			queue = append(queue, Pulse{module.name(), dst, outFreq})
+		}
+	}
+	return
+}
+
+type Broadcaster []string
+
+func (broadcaster Broadcaster) name() string {
+	return "broadcaster"
+}
+
+func (broadcaster Broadcaster) dsts() []string {
+	return broadcaster
+}
+
+func (broadcaster Broadcaster) connect(src string) {
+	panic(src)
+}
+
+func (broadcaster Broadcaster) apply(in Pulse) Frequency {
+	return in.freq
+}
+
+func (broadcaster Broadcaster) reset() {
+}
+
+func (broadcaster Broadcaster) attrs() graph.Attrs {
+	return graph.Attrs{"shape": "ellipse"}
+}
+
+type Sink string
+
+func (sink Sink) name() string {
+	return string(sink)
+}
+
+func (sink Sink) dsts() []string {
+	return nil
+}
+
+func (sink Sink) connect(src string) {
+}
+
+func (sink Sink) apply(in Pulse) Frequency {
+	return None
+}
+
+func (sink Sink) reset() {
+}
+
+func (sink Sink) attrs() graph.Attrs {
+	return graph.Attrs{"shape": "circle"}
+}
+
+type FlipFlop struct {
+	_name string
+	state byte
+	_dsts []string
+}
+
+func (flipFlop *FlipFlop) name() string {
+	return flipFlop._name
+}
+
+func (flipFlop *FlipFlop) dsts() []string {
+	return flipFlop._dsts
+}
+
+func (flipFlop *FlipFlop) connect(src string) {
+}
+
+func (flipFlop *FlipFlop) apply(in Pulse) Frequency {
+	if in.freq == High {
+		return None
+	}
+	flipFlop.state = 1 - flipFlop.state
+	return Frequency(flipFlop.state)
+}
+
+func (flipFlop *FlipFlop) reset() {
+	flipFlop.state = 0
+}
+
+func (flipFlop *FlipFlop) String() string {
+	return fmt.Sprintf("&%v->%v", flipFlop.state, flipFlop._dsts)
+}
+
+func (flipFlop *FlipFlop) attrs() graph.Attrs {
+	return graph.Attrs{"label": "%" + flipFlop._name, "shape": "box", "color": "#7edf68"}
+}
+
+type Conjunction struct {
+	_name string
+	srcs  map[string]Frequency
+	_dsts []string
+}
+
+func (conjunction *Conjunction) name() string {
+	return conjunction._name
+}
+
+func (conjunction *Conjunction) dsts() []string {
+	return conjunction._dsts
+}
+
+func (conjunction *Conjunction) connect(src string) {
+	conjunction.srcs[src] = Low
+}
+
+func (conjunction *Conjunction) apply(in Pulse) Frequency {
+	conjunction.srcs[in.src] = in.freq
+	for _, inFreq := range conjunction.srcs {
+		if inFreq == Low {
+			return High
+		}
+	}
+	return Low
+}
+
+func (conjunction *Conjunction) reset() {
+	for src := range conjunction.srcs {
+		conjunction.srcs[src] = Low
+	}
+}
+
+func (conjunction *Conjunction) String() string {
+	return fmt.Sprintf("&%v->%v", conjunction.srcs, conjunction._dsts)
+}
+
+func (conjunction *Conjunction) attrs() graph.Attrs {
+	return graph.Attrs{"label": "&" + conjunction._name, "shape": "diamond", "color": "#ffa400"}
+}
+
+var moduleRe = regexp.MustCompile(`^([&%]?)(\w+) -> (\w+(?:, \w+)*)$`)
+
+func parseModule(line string) (Module, error) {
+	match := moduleRe.FindStringSubmatch(line)
+	if match == nil {
+		return nil, errors.New("expected a module with destinations")
+	}
+	kind := match[1]
+	name := match[2]
+	dsts := utils.Fields(match[3], ", ")
+	switch {
+	case name == "broadcaster" && kind == "":
+		return Broadcaster(dsts), nil
+	case kind == "%":
+		return &FlipFlop{name, 0, dsts}, nil
+	case kind == "&":
+		return &Conjunction{name, make(map[string]Frequency), dsts}, nil
+	default:
+		return nil, fmt.Errorf("module %s has no type", name)
+	}
+}
+
+func appendModule(modules Modules, module Module) Modules {
+	modules[module.name()] = module
+	return modules
+}
+
+func (modules Modules) init() Modules {
+	for src, srcModule := range modules {
+		for _, dst := range srcModule.dsts() {
+			dstModule, exist := modules[dst]
+			if exist {
+				dstModule.connect(src)
+			} else {
+				modules[dst] = Sink(dst)
+			}
+		}
+	}
+	return modules
+}
+
+func (modules Modules) toGraph() *graph.Graph {
+	g := graph.New("modules", true)
+	for name, module := range modules {
+		g.AddNode(name, module.attrs())
+		for _, dst := range module.dsts() {
+			g.AddEdge(name, dst, nil)
+		}
+	}
+	g.Sort()
+	return g
+}
+
+func (modules Modules) reset() {
+	for _, module := range modules {
+		module.reset()
+	}
+}
+
+func (modules Modules) productAfterPushingButton(times int) int {
+	modules.reset()
+	totalLow, totalHigh := 0, 0
+	for i := 0; i < times; i++ {
+		low, high := modules.pushButton(nil)
+		totalLow += low
+		totalHigh += high
+	}
+	return totalHigh * totalLow
+}
+
+func (modules Modules) srcs(dst string) (srcs []string) {
+	for name, module := range modules {
+		for _, moduleDst := range module.dsts() {
+			if moduleDst == dst {
+				srcs = append(srcs, name)
+			}
+		}
+	}
+	return
+}
+
+// The sink is fed by a single conjunction, whose inputs each send a high pulse
+// on a fixed cycle, so the first low pulse comes when all the cycles align.
+func (modules Modules) pushesUntilLowPulse(ctx context.Context, sink string) (int, error) {
+	modules.reset()
+	feeders := modules.srcs(sink)
+	if len(feeders) != 1 {
+		return 0, fmt.Errorf("%s is fed by %d modules, not a single conjunction", sink, len(feeders))
+	}
+	conjunction := feeders[0]
+	if _, ok := modules[conjunction].(*Conjunction); !ok {
+		return 0, fmt.Errorf("%s is fed by %s, which is not a conjunction", sink, conjunction)
+	}
+	cycles := make(map[string]int)
+	for _, src := range modules.srcs(conjunction) {
+		cycles[src] = 0
+	}
+	found := 0
+	for n := 1; found < len(cycles); n++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		modules.pushButton(func(pulse Pulse) {
+			if pulse.dst == conjunction && pulse.freq == High && cycles[pulse.src] == 0 {
+				cycles[pulse.src] = n
+				found++
+			}
+		})
+	}
+	result := 1
+	for _, cycle := range cycles {
+		result = utils.LCM(result, cycle)
+	}
+	return result, nil
+}
+
+type Options struct {
+	Pushes int    `opt:"pushes" default:"1000" min:"0" usage:"button pushes to count pulses for in part 1"`
+	Sink   string `opt:"sink" default:"rx" usage:"module waiting for a low pulse in part 2"`
+}
+
+type Network struct {
+	modules Modules
+	opts    Options
+}
+
+func (network Network) Part1(ctx context.Context) (int, error) {
+	return network.modules.productAfterPushingButton(network.opts.Pushes), nil
+}
+
+func (network Network) Part2(ctx context.Context) (int, error) {
+	return network.modules.pushesUntilLowPulse(ctx, network.opts.Sink)
+}
+
+func (network Network) ExportGraph() *graph.Graph {
+	return network.modules.toGraph()
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 20, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	modules, err := utils.ProcessReader(r, Modules{}, parseModule, appendModule)
+	if err != nil {
+		return nil, err
+	}
+	if _, found := modules["broadcaster"]; !found {
+		return nil, fmt.Errorf("%s: no broadcaster", utils.InputName(r))
+	}
+	return Network{modules.init(), opts}, nil
+}
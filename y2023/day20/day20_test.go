@@ -0,0 +1,32 @@
+package day20
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 20,
+		golden.Case{Input: "day20_test.txt", Part1: 11687500},
+		golden.Case{Input: "day20_test.txt", Options: registry.Options{"pushes": "1", "sink": "output"}, Part1: 16, Part2: 1},
+		golden.Case{Input: "day20_test.txt", Options: registry.Options{"sink": "inv"}, Err: "inv is fed by a, which is not a conjunction"},
+		golden.Case{Input: "day20_test.txt", Options: registry.Options{"sink": "zz"}, Err: "zz is fed by 0 modules"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 20)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 20)
+}
+
+func FuzzParseModule(f *testing.F) {
+	golden.FuzzLines(f, 2023, 20, parseModule)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 20)
+}
@@ -0,0 +1,56 @@
+package day20
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+func init() {
+	gen.Register(2023, 20, Generate)
+}
+
+// Generate writes a network shaped like the real ones: the broadcaster
+// starts binary counters of flip-flops, each with a conjunction that
+// resets its counter when it reaches a random odd target. The conjunctions
+// go through inverters to the conjunction in front of rx, so rx gets a low
+// pulse after the LCM of the targets.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	counters, bits := size.Pick(2, 3, 4), size.Pick(6, 9, 12)
+	names := gen.Names(rng, counters*(bits+2)+1, 2, "rx")
+	gate := names[0]
+	names = names[1:]
+	var modules, starts []string
+	module := func(kind, name string, dsts ...string) {
+		modules = append(modules, fmt.Sprintf("%s%s -> %s", kind, name, strings.Join(dsts, ", ")))
+	}
+	for range counters {
+		flipFlops, hub, inverter := names[:bits], names[bits], names[bits+1]
+		names = names[bits+2:]
+		target := 1<<(bits-1) | gen.Between(rng, 0, 1<<(bits-2)-1)<<1 | 1
+		hubDsts := []string{inverter, flipFlops[0]}
+		for i, flipFlop := range flipFlops {
+			var dsts []string
+			if i+1 < bits {
+				dsts = append(dsts, flipFlops[i+1])
+			}
+			if target&(1<<i) != 0 {
+				dsts = append(dsts, hub)
+			} else {
+				hubDsts = append(hubDsts, flipFlop)
+			}
+			module("%", flipFlop, dsts...)
+		}
+		module("&", hub, hubDsts...)
+		module("&", inverter, gate)
+		starts = append(starts, flipFlops[0])
+	}
+	module("&", gate, "rx")
+	rng.Shuffle(len(modules), func(i, j int) {
+		modules[i], modules[j] = modules[j], modules[i]
+	})
+	module("", "broadcaster", starts...)
+	w.WriteString(strings.Join(modules, "\n") + "\n")
+}
@@ -0,0 +1,225 @@
+package day21
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/geom"
+	"advent/utils/grid"
+	"advent/utils/render"
+	"context"
+	"fmt"
+	"io"
+)
+
+type Tile rune
+
+type State struct {
+	tiles     grid.Grid[Tile]
+	positions map[geom.Point]bool
+}
+
+func (state State) walk(steps int) State {
+	for n := 0; n < steps; n++ {
+		next := make(map[geom.Point]bool)
+		for pos := range state.positions {
+			for neighbor := range state.tiles.Neighbors4(pos) {
+				if state.tiles.At(neighbor) == '.' {
+					next[neighbor] = true
+				}
+			}
+		}
+		state.positions = next
+	}
+	return state
+}
+
+func (state State) posCount() int {
+	return len(state.positions)
+}
+
+func (state State) reset(i, j int) State {
+	return State{state.tiles, map[geom.Point]bool{{I: i, J: j}: true}}
+}
+
+func (state State) String() string {
+	return state.tiles.Render(func(pos geom.Point, tile Tile) string {
+		if state.positions[pos] {
+			return "O"
+		}
+		return string(tile)
+	})
+}
+
+func parseTile(r rune) (Tile, error) {
+	switch r {
+	case '.', '#', 'S':
+		return Tile(r), nil
+	default:
+		return 0, fmt.Errorf("unknown tile %q", r)
+	}
+}
+
+func (state State) infiniteWalkPosCountOptimized(steps int) (total int) {
+	size := len(state.tiles)
+	center := size / 2
+	R := (steps - center) / size
+	{
+		fullCount := [2]int{1, 0}
+		idx := 1
+		incr := 4
+		for i := 1; i < R; i++ {
+			fullCount[idx] += incr
+			incr += 4
+			idx = 1 - idx
+		}
+		state := state.reset(center, center).walk(size + (R % 2))
+		total += fullCount[0] * state.posCount()
+		total += fullCount[1] * state.walk(1).posCount()
+	}
+	{
+		state := state.reset(0, 0).walk(center - 1)
+		total += R * state.posCount()
+		total += (R - 1) * state.walk(size).posCount()
+	}
+	total += state.reset(0, center).walk(size - 1).posCount()
+	{
+		state := state.reset(0, size-1).walk(center - 1)
+		total += R * state.posCount()
+		total += (R - 1) * state.walk(size).posCount()
+	}
+	total += state.reset(center, size-1).walk(size - 1).posCount()
+	{
+		state := state.reset(size-1, size-1).walk(center - 1)
+		total += R * state.posCount()
+		total += (R - 1) * state.walk(size).posCount()
+	}
+	total += state.reset(size-1, center).walk(size - 1).posCount()
+	{
+		state := state.reset(size-1, 0).walk(center - 1)
+		total += R * state.posCount()
+		total += (R - 1) * state.walk(size).posCount()
+	}
+	total += state.reset(center, 0).walk(size - 1).posCount()
+	return
+}
+
+// maxInfiniteWalk bounds the steps walked one at a time, since the plots to
+// remember grow with the square of the steps.
+const maxInfiniteWalk = 1000
+
+// clearCross tells whether the middle row and column and the edges of the
+// garden have no rocks, so that the walk reaches every repeated garden
+// straight through them.
+func (state State) clearCross() bool {
+	size := len(state.tiles)
+	for n := range size {
+		for _, pos := range []geom.Point{{I: size / 2, J: n}, {I: n, J: size / 2}, {I: 0, J: n}, {I: size - 1, J: n}, {I: n, J: 0}, {I: n, J: size - 1}} {
+			if state.tiles.At(pos) != '.' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// infiniteWalkPosCount uses the optimized count when a square garden starts
+// in the middle of clear rows and columns and the walk ends on the edge of a
+// repeated garden, as it does for the real input, and otherwise walks the
+// infinite map one step at a time.
+func (state State) infiniteWalkPosCount(ctx context.Context, steps int) (int, error) {
+	size := len(state.tiles)
+	center := geom.Point{I: size / 2, J: size / 2}
+	if state.tiles.Width() == size && state.positions[center] && len(state.positions) == 1 &&
+		steps >= center.I+size && (steps-center.I)%size == 0 && state.clearCross() {
+		return state.infiniteWalkPosCountOptimized(steps), nil
+	}
+	if steps > maxInfiniteWalk {
+		return 0, fmt.Errorf("cannot walk %d steps on a %dx%d garden: only a multiple of the side plus half of it, or up to %d steps, is supported",
+			steps, state.tiles.Height(), state.tiles.Width(), maxInfiniteWalk)
+	}
+	return state.infiniteWalk(ctx, steps)
+}
+
+// infiniteWalk counts the plots reached in exactly steps steps on the
+// repeated garden, which are the ones reached earlier with the same parity.
+func (state State) infiniteWalk(ctx context.Context, steps int) (int, error) {
+	height, width := state.tiles.Height(), state.tiles.Width()
+	visited := make(map[geom.Point]bool)
+	var frontier []geom.Point
+	for pos := range state.positions {
+		visited[pos] = true
+		frontier = append(frontier, pos)
+	}
+	count := 0
+	for n := 0; ; n++ {
+		if n%2 == steps%2 {
+			count += len(frontier)
+		}
+		if n == steps {
+			return count, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		var next []geom.Point
+		for _, pos := range frontier {
+			for _, dir := range geom.Dirs {
+				neighbor := pos.Step(dir)
+				wrapped := geom.Point{I: (neighbor.I%height + height) % height, J: (neighbor.J%width + width) % width}
+				if !visited[neighbor] && state.tiles.At(wrapped) == '.' {
+					visited[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		frontier = next
+	}
+}
+
+type Options struct {
+	Steps         int `opt:"steps" default:"64" min:"0" usage:"steps to walk in part 1"`
+	InfiniteSteps int `opt:"infinite-steps" default:"26501365" min:"0" usage:"steps to walk on the infinite map in part 2"`
+}
+
+type Garden struct {
+	state State
+	opts  Options
+}
+
+func (garden Garden) Part1(ctx context.Context) (int, error) {
+	return garden.state.walk(garden.opts.Steps).posCount(), nil
+}
+
+func (garden Garden) Part2(ctx context.Context) (int, error) {
+	return garden.state.infiniteWalkPosCount(ctx, garden.opts.InfiniteSteps)
+}
+
+// Draw pictures the plots reached in the steps of part 1.
+func (garden Garden) Draw() render.Picture {
+	state := garden.state.walk(garden.opts.Steps)
+	return render.Draw(state.tiles, func(p geom.Point, tile Tile) render.Cell {
+		if state.positions[p] {
+			return render.Cell{Rune: 'O', Color: render.Green}
+		}
+		return render.Cell{Rune: rune(tile)}
+	})
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 21, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	tiles, err := grid.Parse(r, parseTile)
+	if err != nil {
+		return nil, err
+	}
+	start, found := tiles.Find(func(tile Tile) bool {
+		return tile == 'S'
+	})
+	if !found {
+		return nil, fmt.Errorf("%s: no starting position", utils.InputName(r))
+	}
+	tiles.Set(start, '.')
+	return Garden{State{tiles, map[geom.Point]bool{start: true}}, opts}, nil
+}
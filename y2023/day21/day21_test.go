@@ -0,0 +1,33 @@
+package day21
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 21,
+		golden.Case{Input: "day21_test.txt", Part1: 113},
+		golden.Case{Input: "day21_test.txt", Options: registry.Options{"steps": "6", "infinite-steps": "37"}, Part1: 49, Part2: 1444},
+		golden.Case{Input: "day21_test.txt", Options: registry.Options{"infinite-steps": "67"}, Part2: 4624},
+		golden.Case{Input: "day21_test.txt", Options: registry.Options{"infinite-steps": "5"}, Part2: 36},
+		golden.Case{Input: "day21_test.txt", Options: registry.Options{"infinite-steps": "10"}, Part2: 121},
+		golden.Case{Input: "day21_test.txt", Options: registry.Options{"infinite-steps": "50"}, Part2: 2601},
+		golden.Case{Input: "day21_test2.txt", Options: registry.Options{"steps": "6", "infinite-steps": "10"}, Part1: 16, Part2: 50},
+		golden.Case{Input: "day21_test2.txt", Options: registry.Options{"infinite-steps": "100"}, Part2: 6536},
+		golden.Case{Input: "day21_test2.txt", Options: registry.Options{"infinite-steps": "500"}, Part2: 167004},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 21)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 21)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 21)
+}
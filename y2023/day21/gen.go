@@ -0,0 +1,60 @@
+package day21
+
+import (
+	"advent/gen"
+	"advent/utils/geom"
+	"bufio"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 21, Generate)
+}
+
+// Generate writes a square garden with S in the middle of clear rows and
+// columns through the center and along the edges, like the real one.
+// Plots that cannot be reached are turned into rocks. The default steps of
+// part 2 are a multiple of the side plus half of it only for a side of 131,
+// so every size keeps that side and larger sizes get fewer rocks, leaving
+// more plots to reach.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	const side = 131
+	rocks := size.Pick(5, 9, 15)
+	center := side / 2
+	tiles := make([][]byte, side)
+	for i := range tiles {
+		tiles[i] = make([]byte, side)
+		for j := range tiles[i] {
+			tiles[i][j] = '.'
+			clear := i == 0 || j == 0 || i == side-1 || j == side-1 || i == center || j == center
+			if !clear && rng.IntN(rocks) == 0 {
+				tiles[i][j] = '#'
+			}
+		}
+	}
+	reached := map[geom.Point]bool{{I: center, J: center}: true}
+	queue := []geom.Point{{I: center, J: center}}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, dir := range geom.Dirs {
+			q := p.Step(dir)
+			if q.I >= 0 && q.I < side && q.J >= 0 && q.J < side && tiles[q.I][q.J] == '.' && !reached[q] {
+				reached[q] = true
+				queue = append(queue, q)
+			}
+		}
+	}
+	for i, row := range tiles {
+		for j := range row {
+			if !reached[geom.Point{I: i, J: j}] {
+				row[j] = '#'
+			}
+		}
+	}
+	tiles[center][center] = 'S'
+	for _, row := range tiles {
+		w.Write(row)
+		w.WriteByte('\n')
+	}
+}
@@ -1,10 +1,13 @@
 package day22
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
-	"strconv"
 	"strings"
 )
 
@@ -56,7 +59,7 @@ func (system System) String() string {
 }
 
 func (system System) countOptionalBricks() int {
-	mandatoryBricks := make([]bool, len(system))
+	mandatoryBricks := make(map[int]bool, len(system))
 	for _, brick := range system {
 		if len(brick.supportedBy) == 1 {
 			mandatoryBricks[brick.supportedBy[0]] = true
@@ -121,20 +124,23 @@ func placeBricks(bricks []Brick) (system System) {
 	return
 }
 
-func parseBrick(line string) (brick Brick) {
-	for i, coordStr := range utils.Fields(line, "~") {
-		coord := &brick[i]
-		for j, numStr := range utils.Fields(coordStr, ",") {
-			num, _ := strconv.Atoi(numStr)
-			switch j {
-			case 0:
-				coord.x = num
-			case 1:
-				coord.y = num
-			case 2:
-				coord.z = num
-			}
+func parseBrick(line string) (brick Brick, err error) {
+	coordStrs := utils.Fields(line, "~")
+	if len(coordStrs) != 2 {
+		return brick, errors.New("expected two ends separated by ~")
+	}
+	for i, coordStr := range coordStrs {
+		nums, err := utils.ParseNumberFields(utils.Fields(coordStr, ","))
+		if err != nil {
+			return brick, err
+		}
+		if len(nums) != 3 {
+			return brick, fmt.Errorf("expected 3 coordinates, got %d", len(nums))
 		}
+		if nums[2] < 1 {
+			return brick, errors.New("brick is below the ground")
+		}
+		brick[i] = Coords{nums[0], nums[1], nums[2]}
 	}
 	if brick[1].z < brick[0].z {
 		brick[0], brick[1] = brick[1], brick[0]
@@ -142,12 +148,24 @@ func parseBrick(line string) (brick Brick) {
 	return
 }
 
-func appendBrick(bricks []Brick, brick Brick) []Brick {
-	return append(bricks, brick)
+type Snapshot []Brick
+
+func (snapshot Snapshot) Part1(ctx context.Context) (int, error) {
+	return placeBricks(snapshot).countOptionalBricks(), nil
+}
+
+func (snapshot Snapshot) Part2(ctx context.Context) (int, error) {
+	return placeBricks(snapshot).sumOfFallingBricks(), nil
+}
+
+func appendBrick(snapshot Snapshot, brick Brick) Snapshot {
+	return append(snapshot, brick)
+}
+
+func init() {
+	registry.Register(2023, 22, Parse)
 }
 
-func Run() int {
-	bricks := utils.ProcessInput("day22.txt", nil, parseBrick, appendBrick)
-	system := placeBricks(bricks)
-	return system.sumOfFallingBricks()
+func Parse(r io.Reader) (registry.Solver, error) {
+	return utils.ProcessReader(r, Snapshot{}, parseBrick, appendBrick)
 }
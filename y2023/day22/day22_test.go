@@ -0,0 +1,28 @@
+package day22
+
+import (
+	"advent/golden"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 22,
+		golden.Case{Input: "day22_test.txt", Part1: 4, Part2: 5},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 22)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 22)
+}
+
+func FuzzParseBrick(f *testing.F) {
+	golden.FuzzLines(f, 2023, 22, parseBrick)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 22)
+}
@@ -0,0 +1,51 @@
+package day22
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 22, Generate)
+}
+
+// Generate writes a snapshot of straight bricks up to five cubes long
+// floating over a 10 by 10 area without overlapping each other.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	bricks, height := size.Pick(20, 300, 1200), size.Pick(15, 150, 350)
+	taken := make(map[Coords]bool)
+	for placed := 0; placed < bricks; {
+		from := Coords{rng.IntN(10), rng.IntN(10), gen.Between(rng, 1, height)}
+		to := from
+		switch length := gen.Between(rng, 0, 4); rng.IntN(3) {
+		case 0:
+			to.x = min(from.x+length, 9)
+		case 1:
+			to.y = min(from.y+length, 9)
+		default:
+			to.z += length
+		}
+		var cubes []Coords
+		for x := from.x; x <= to.x; x++ {
+			for y := from.y; y <= to.y; y++ {
+				for z := from.z; z <= to.z; z++ {
+					cubes = append(cubes, Coords{x, y, z})
+				}
+			}
+		}
+		free := true
+		for _, cube := range cubes {
+			free = free && !taken[cube]
+		}
+		if !free {
+			continue
+		}
+		for _, cube := range cubes {
+			taken[cube] = true
+		}
+		fmt.Fprintf(w, "%d,%d,%d~%d,%d,%d\n", from.x, from.y, from.z, to.x, to.y, to.z)
+		placed++
+	}
+}
@@ -0,0 +1,344 @@
+package day23
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/geom"
+	"advent/utils/graph"
+	"advent/utils/grid"
+	"advent/utils/logger"
+	"advent/utils/render"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type Tile rune
+
+const (
+	Forest     Tile = '#'
+	Path       Tile = '.'
+	SlopeUp    Tile = '^'
+	SlopeRight Tile = '>'
+	SlopeLeft  Tile = '<'
+	SlopeDown  Tile = 'v'
+)
+
+type Cell struct {
+	tile    Tile
+	slope   geom.Dir
+	visited bool
+}
+
+type Labyrinth struct {
+	grid.Grid[Cell]
+}
+
+func (labyrinth Labyrinth) String() string {
+	return render.ANSIString(render.Draw(labyrinth.Grid, func(_ geom.Point, cell Cell) render.Cell {
+		if cell.visited {
+			return render.Cell{Rune: rune(cell.tile), Color: render.Red}
+		}
+		return render.Cell{Rune: rune(cell.tile)}
+	}))
+}
+
+func (labyrinth Labyrinth) withoutSlopes() Labyrinth {
+	return Labyrinth{grid.Map(labyrinth.Grid, func(cell Cell) Cell {
+		switch cell.tile {
+		case SlopeUp, SlopeLeft, SlopeRight, SlopeDown:
+			cell.tile = Path
+		}
+		return cell
+	})}
+}
+
+func (labyrinth Labyrinth) findLongestPath(ctx context.Context, p geom.Point) (int, bool) {
+	if done(ctx) {
+		return 0, false
+	}
+	if !labyrinth.InBounds(p) {
+		return 0, false
+	}
+	cell := &labyrinth.Grid[p.I][p.J]
+	if cell.visited {
+		return 0, false
+	}
+	cell.visited = true
+	defer func() {
+		cell.visited = false
+	}()
+	switch cell.tile {
+	case Forest:
+		return 0, false
+	case Path:
+		if p.I == labyrinth.Height()-1 {
+			return 1, true
+		}
+		maxPath, maxFound := 0, false
+		for _, dir := range geom.Dirs {
+			path, found := labyrinth.findLongestPath(ctx, p.Step(dir))
+			if found && path > maxPath {
+				maxPath = path
+				maxFound = true
+			}
+		}
+		return maxPath + 1, maxFound
+	default:
+		path, found := labyrinth.findLongestPath(ctx, p.Step(cell.slope))
+		return path + 1, found
+	}
+}
+
+// done is checked on every step of the searches, so unlike ctx.Err() it takes no lock
+func done(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+type Node int
+
+type Link struct {
+	node Node
+	len  int
+}
+
+type Graph struct {
+	src, dst Node
+	links    map[Node][]Link
+}
+
+func (graph Graph) longestPath(ctx context.Context) (int, error) {
+	result, found := graph.longestPathRec(
+		ctx,
+		0,
+		make([]bool, len(graph.links)),
+		graph.src,
+	)
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errNoPath
+	}
+	return result, nil
+}
+
+func (graph Graph) longestPathRec(ctx context.Context, acc int, visited []bool, curr Node) (int, bool) {
+	if done(ctx) {
+		return 0, false
+	}
+	if curr == graph.dst {
+		return acc, true
+	}
+	fromIdx := int(curr)
+	if visited[fromIdx] {
+		return 0, false
+	}
+	visited[fromIdx] = true
+	defer func() {
+		visited[fromIdx] = false
+	}()
+	longestPath, longestPathFound := acc, false
+	for _, link := range graph.links[curr] {
+		path, found := graph.longestPathRec(ctx, acc+link.len, visited, link.node)
+		if found && path > longestPath {
+			longestPath, longestPathFound = path, true
+		}
+	}
+	return longestPath, longestPathFound
+}
+
+type GraphBuilder struct {
+	nodes map[geom.Point]Node
+	links map[Node][]Link
+}
+
+func (gb *GraphBuilder) connect(a, b Node, len int) {
+NEXT_PAIR:
+	for _, pair := range [][2]Node{{a, b}, {b, a}} {
+		node := pair[0]
+		newLink := Link{pair[1], len}
+		for _, existingLink := range gb.links[pair[0]] {
+			if existingLink == newLink {
+				continue NEXT_PAIR
+			}
+		}
+		gb.links[node] = append(gb.links[node], newLink)
+	}
+}
+
+func (gb *GraphBuilder) walk(labyrinth Labyrinth, link Link, prev, curr geom.Point) {
+	if node, exists := gb.nodes[curr]; exists {
+		gb.connect(node, link.node, link.len)
+		return
+	}
+	nexts := make([]geom.Point, 0, 3)
+	for next := range labyrinth.Neighbors4(curr) {
+		if next != prev && labyrinth.At(next).tile == Path {
+			nexts = append(nexts, next)
+		}
+	}
+	if len(nexts) == 1 {
+		link.len++
+		gb.walk(labyrinth, link, curr, nexts[0])
+		return
+	}
+	node := Node(len(gb.nodes))
+	gb.nodes[curr] = node
+	gb.connect(node, link.node, link.len)
+	for _, next := range nexts {
+		gb.walk(labyrinth, Link{node, 1}, curr, next)
+	}
+}
+
+func (labyrinth Labyrinth) buildGraph() *GraphBuilder {
+	src := Node(0)
+	gb := &GraphBuilder{
+		nodes: map[geom.Point]Node{{I: 0, J: 1}: src},
+		links: make(map[Node][]Link),
+	}
+	gb.walk(labyrinth, Link{src, 1}, geom.Point{I: 0, J: 1}, geom.Point{I: 1, J: 1})
+	return gb
+}
+
+// exit is the node on the bottom row, if a trail gets there.
+func (gb *GraphBuilder) exit(labyrinth Labyrinth) (Node, bool) {
+	for p, node := range gb.nodes {
+		if p.I == labyrinth.Height()-1 {
+			return node, true
+		}
+	}
+	return 0, false
+}
+
+func (labyrinth Labyrinth) toGraph() (graph Graph, err error) {
+	gb := labyrinth.buildGraph()
+	dst, found := gb.exit(labyrinth)
+	if !found {
+		return graph, errNoPath
+	}
+	graph.src = Node(0)
+	graph.dst = dst
+	graph.links = gb.links
+	return
+}
+
+var errNoPath = errors.New("no trail leads to the bottom row")
+
+func parseCell(r rune) (Cell, error) {
+	switch tile := Tile(r); tile {
+	case Forest, Path:
+		return Cell{tile: tile}, nil
+	case SlopeUp, SlopeRight, SlopeLeft, SlopeDown:
+		slope, err := geom.ParseDir(r)
+		return Cell{tile: tile, slope: slope}, err
+	default:
+		return Cell{}, fmt.Errorf("unknown tile %q", r)
+	}
+}
+
+type Options struct {
+	IgnoreSlopes *bool `opt:"ignore-slopes" usage:"walk slopes in any direction (default: part 2 only)"`
+}
+
+type Hike struct {
+	labyrinth Labyrinth
+	opts      Options
+}
+
+func (hike Hike) longestPath(ctx context.Context, ignoreSlopes bool) (int, error) {
+	if !ignoreSlopes {
+		path, found := hike.labyrinth.findLongestPath(ctx, geom.Point{I: 0, J: 1})
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if !found {
+			return 0, errNoPath
+		}
+		// both start and end tiles are counted
+		return path - 1, nil
+	}
+	labyrinth := hike.labyrinth.withoutSlopes()
+	trails, err := labyrinth.toGraph()
+	if err != nil {
+		return 0, err
+	}
+	logger.From(ctx).Debug("trails", "junctions", len(trails.links))
+	return trails.longestPath(ctx)
+}
+
+// Draw pictures the forest and the junctions of the graph searched in part 2.
+func (hike Hike) Draw() render.Picture {
+	nodes := hike.labyrinth.withoutSlopes().buildGraph().nodes
+	return render.Draw(hike.labyrinth.Grid, func(p geom.Point, cell Cell) render.Cell {
+		if _, found := nodes[p]; found {
+			return render.Cell{Rune: 'O', Color: render.Red}
+		}
+		if cell.tile == Forest {
+			return render.Cell{Rune: rune(cell.tile), Color: render.Green}
+		}
+		return render.Cell{Rune: rune(cell.tile)}
+	})
+}
+
+// ExportGraph is the graph of junctions searched in part 2, labelled with
+// their positions and with the lengths of the trails between them.
+func (hike Hike) ExportGraph() *graph.Graph {
+	labyrinth := hike.labyrinth.withoutSlopes()
+	gb := labyrinth.buildGraph()
+	src := Node(0)
+	// without an exit dst is the source, which keeps its color
+	dst, _ := gb.exit(labyrinth)
+	g := graph.New("trails", false)
+	for p, node := range gb.nodes {
+		attrs := graph.Attrs{"label": fmt.Sprintf("%d,%d", p.I, p.J)}
+		switch node {
+		case src:
+			attrs["color"] = "green"
+		case dst:
+			attrs["color"] = "red"
+		}
+		g.AddNode(strconv.Itoa(int(node)), attrs)
+	}
+	for a, links := range gb.links {
+		for _, link := range links {
+			// links are kept in both directions
+			if a < link.node {
+				g.AddEdge(strconv.Itoa(int(a)), strconv.Itoa(int(link.node)), graph.Attrs{"label": strconv.Itoa(link.len)})
+			}
+		}
+	}
+	g.Sort()
+	return g
+}
+
+func (hike Hike) Part1(ctx context.Context) (int, error) {
+	return hike.longestPath(ctx, utils.Or(hike.opts.IgnoreSlopes, false))
+}
+
+func (hike Hike) Part2(ctx context.Context) (int, error) {
+	return hike.longestPath(ctx, utils.Or(hike.opts.IgnoreSlopes, true))
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 23, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	cells, err := grid.Parse(r, parseCell)
+	if err != nil {
+		return nil, err
+	}
+	labyrinth := Labyrinth{cells}
+	if labyrinth.Height() < 3 || labyrinth.Width() < 3 || labyrinth.Grid[0][1].tile != Path {
+		return nil, fmt.Errorf("%s: the trail must start at the second tile of the top row", utils.InputName(r))
+	}
+	return Hike{labyrinth, opts}, nil
+}
@@ -0,0 +1,29 @@
+package day23
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 23,
+		golden.Case{Input: "day23_test.txt", Part1: 94, Part2: 154},
+		golden.Case{Input: "day23_test.txt", Options: registry.Options{"ignore-slopes": "true"}, Part1: 154},
+		golden.Case{Input: "day23_test.txt", Options: registry.Options{"ignore-slopes": "false"}, Part2: 94},
+		golden.Case{Input: "day23_test2.txt", Err: "no trail leads to the bottom row"},
+		golden.Case{Input: "day23_test2.txt", Options: registry.Options{"ignore-slopes": "true"}, Err: "no trail leads to the bottom row"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 23)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 23)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 23)
+}
@@ -0,0 +1,93 @@
+package day23
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 23, Generate)
+}
+
+// Generate writes a forest with a square lattice of junctions, like the
+// real one. The trails between neighboring junctions wind sideways now and
+// then and have slopes at both ends pointing right or down, so part 1
+// cannot go back up.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	n, spacing := size.Pick(3, 4, 6), size.Pick(10, 14, 27)
+	side := 3 + (n-1)*spacing
+	tiles := make([][]byte, side)
+	for i := range tiles {
+		tiles[i] = make([]byte, side)
+		for j := range tiles[i] {
+			tiles[i][j] = byte(Forest)
+		}
+	}
+	tiles[0][1] = byte(Path)
+	tiles[side-1][side-2] = byte(Path)
+	for r := range n {
+		for c := range n - 1 {
+			// along a row, then along a column
+			t := trail{rng, spacing, r > 0, r < n-1}
+			t.draw(1+r*spacing, 1+c*spacing, SlopeRight, func(along, across int) *byte {
+				return &tiles[across][along]
+			})
+			t.draw(1+r*spacing, 1+c*spacing, SlopeDown, func(along, across int) *byte {
+				return &tiles[along][across]
+			})
+		}
+	}
+	for _, row := range tiles {
+		w.Write(row)
+		w.WriteByte('\n')
+	}
+}
+
+// trail may detour to lower rows or columns when before is set and to higher
+// ones when after is set, neither of which is set towards the forest edge.
+type trail struct {
+	rng           *rand.Rand
+	spacing       int
+	before, after bool
+}
+
+// draw lays the trail from the junction at from to the next one, at across
+// from the side. Detours keep to the middle of the trail and within a
+// quarter of the spacing of it, so they never come near other trails.
+func (t trail) draw(across, from int, slope Tile, at func(along, across int) *byte) {
+	to := from + t.spacing
+	reach := t.spacing / 4
+	for along := from; along <= to; along++ {
+		*at(along, across) = byte(Path)
+	}
+	*at(from+1, across) = byte(slope)
+	*at(to-1, across) = byte(slope)
+	for along := from + reach + 2; ; {
+		start := along + t.rng.IntN(3)
+		end := start + gen.Between(t.rng, 2, 5)
+		if end > to-reach-2 {
+			break
+		}
+		along = end + 2
+		if t.rng.IntN(3) == 0 {
+			continue
+		}
+		offset := gen.Between(t.rng, 1, reach)
+		if !t.after || t.before && t.rng.IntN(2) == 0 {
+			offset = -offset
+		}
+		for a := start + 1; a < end; a++ {
+			*at(a, across) = byte(Forest)
+			*at(a, across+offset) = byte(Path)
+		}
+		step := 1
+		if offset < 0 {
+			step = -1
+		}
+		for k := step; k != offset+step; k += step {
+			*at(start, across+k) = byte(Path)
+			*at(end, across+k) = byte(Path)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package day24
 
 import (
+	"advent/registry"
 	"advent/utils"
+	"advent/utils/logger"
+	"context"
 	"fmt"
+	"io"
 	"math/big"
 	"strconv"
 	"strings"
@@ -42,29 +46,33 @@ func (h1 HailDim) intersectTime(h2 HailDim) *big.Rat {
 
 type Storm []Hail
 
-func (storm Storm) checkCollisions(h1 Hail) {
-	for _, h2 := range storm {
+func (storm Storm) checkCollisions(ctx context.Context, h1 Hail) error {
+	for i, h2 := range storm {
 		times := [3]*big.Rat{
 			h1.dimX().intersectTime(h2.dimX()),
 			h1.dimY().intersectTime(h2.dimY()),
 			h1.dimZ().intersectTime(h2.dimZ()),
 		}
 		for _, time := range times {
-			if time == nil || time.Sign() < 0 {
-				panic("time in the past")
+			if time == nil {
+				return fmt.Errorf("the rock never hits hailstone %d", i+1)
+			}
+			if time.Sign() < 0 {
+				return fmt.Errorf("the rock hits hailstone %d in the past", i+1)
 			}
 		}
 		first := times[0]
 		if first.Sign() == 0 {
-			panic("nope")
+			return fmt.Errorf("the rock starts where hailstone %d is", i+1)
 		}
 		for _, time := range times[1:] {
 			if time.Sign() != 0 && time.Cmp(first) != 0 {
-				panic("no intersection")
+				return fmt.Errorf("the rock misses hailstone %d", i+1)
 			}
 		}
-		fmt.Println("Collides with", h2, "at", first)
+		logger.Trace(ctx, "collision", "hail", i, "time", first)
 	}
+	return nil
 }
 
 type Matrix struct {
@@ -83,12 +91,11 @@ func (m Matrix) String() string {
 	return sb.String()
 }
 
-func (m Matrix) solve() {
+func (m Matrix) solve() error {
 	for i, iRow := range m.coeff {
 		div := new(big.Rat).Set(iRow[i])
 		if div.Sign() == 0 {
-			fmt.Println(m)
-			panic("zero")
+			return fmt.Errorf("the first hailstones do not pin down the rock, zero pivot in\n%v", m)
 		}
 		for _, elem := range iRow {
 			elem.Quo(elem, div)
@@ -105,11 +112,12 @@ func (m Matrix) solve() {
 			m.rhs[j].Sub(m.rhs[j], new(big.Rat).Mul(m.rhs[i], mul))
 		}
 	}
+	return nil
 }
 
 // Kudos to @ash42 comment, havent figured out how to turn this into linear equations myself
 // https://github.com/ash42/adventofcode/blob/95b412fe20da44002192e69d267733375241a9cd/adventofcode2023/src/nl/michielgraat/adventofcode2023/day24/Day24.java#L82-L123
-func (storm Storm) findBullet() Hail {
+func (storm Storm) findBullet(ctx context.Context) (Hail, error) {
 	m := Matrix{
 		coeff: make([][]*big.Rat, 6),
 		rhs:   make([]*big.Rat, 6),
@@ -140,21 +148,23 @@ func (storm Storm) findBullet() Hail {
 		}
 		m.rhs[i*2+1] = big.NewRat(-x1*vz1+z1*vx1+x2*vz2-z2*vx2, 1)
 	}
-	fmt.Println(m)
-	m.solve()
-	fmt.Println(m)
+	logger.Trace(ctx, "equations", "matrix", logger.Lazy(m.String))
+	if err := m.solve(); err != nil {
+		return Hail{}, err
+	}
+	logger.Trace(ctx, "solved", "matrix", logger.Lazy(m.String))
 
 	var res [6]int64
 	for i, br := range m.rhs {
-		if br.Denom().Int64() != 1 {
-			panic("not int")
+		if !br.IsInt() || !br.Num().IsInt64() {
+			return Hail{}, fmt.Errorf("the rock has no integer position and velocity, got %v", br)
 		}
 		res[i] = br.Num().Int64()
 	}
 	return Hail{
 		pos: Coords{x: res[0], y: res[1], z: res[2]},
 		vel: Coords{x: res[3], y: res[4], z: res[5]},
-	}
+	}, nil
 }
 
 func (storm Storm) countHailsPathsIntersectingXY(minXY, maxXY int) (count int) {
@@ -235,27 +245,69 @@ func (h Hail) pointsToXY(x, y *big.Rat) bool {
 	return x.Sign() == vx.Sign()
 }
 
-func parseHail(line string) Hail {
+func parseHail(line string) (Hail, error) {
 	var nums [6]int64
-	for i, numStr := range utils.Fields(line, "@, ") {
-		n, _ := strconv.Atoi(numStr)
-		nums[i] = int64(n)
+	numStrs := utils.Fields(line, "@, ")
+	if len(numStrs) != len(nums) {
+		return Hail{}, fmt.Errorf("expected %d numbers, got %d", len(nums), len(numStrs))
+	}
+	for i, numStr := range numStrs {
+		n, err := strconv.ParseInt(numStr, 10, 64)
+		if err != nil {
+			return Hail{}, err
+		}
+		nums[i] = n
 	}
 	return Hail{
 		pos: Coords{x: nums[0], y: nums[1], z: nums[2]},
 		vel: Coords{x: nums[3], y: nums[4], z: nums[5]},
-	}
+	}, nil
 }
 
 func appendHail(storm Storm, hail Hail) Storm {
 	return append(storm, hail)
 }
 
-func Run() int {
-	storm := utils.ProcessInput("day24.txt", nil, parseHail, appendHail)
-	// storm := utils.ProcessInput("day24_test.txt", nil, parseHail, appendHail)
-	//storm.countHailsPathsIntersectingXY(200000000000000, 400000000000000)
-	bullet := storm.findBullet()
-	storm.checkCollisions(bullet)
-	return int(bullet.pos.x + bullet.pos.y + bullet.pos.z)
+type Options struct {
+	AreaMin int `opt:"area-min" default:"200000000000000" usage:"lower X and Y bound of the test area in part 1"`
+	AreaMax int `opt:"area-max" default:"400000000000000" usage:"upper X and Y bound of the test area in part 1"`
+}
+
+type Forecast struct {
+	storm Storm
+	opts  Options
+}
+
+func (forecast Forecast) Part1(ctx context.Context) (int, error) {
+	return forecast.storm.countHailsPathsIntersectingXY(forecast.opts.AreaMin, forecast.opts.AreaMax), nil
+}
+
+func (forecast Forecast) Part2(ctx context.Context) (int, error) {
+	storm := forecast.storm
+	bullet, err := storm.findBullet(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := storm.checkCollisions(ctx, bullet); err != nil {
+		return 0, err
+	}
+	return int(bullet.pos.x + bullet.pos.y + bullet.pos.z), nil
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 24, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	if opts.AreaMin > opts.AreaMax {
+		return nil, fmt.Errorf("area-min %d is above area-max %d", opts.AreaMin, opts.AreaMax)
+	}
+	storm, err := utils.ProcessReader(r, Storm{}, parseHail, appendHail)
+	if err != nil {
+		return nil, err
+	}
+	if len(storm) < 4 {
+		return nil, fmt.Errorf("%s: %d hailstones are not enough to aim the rock, expected at least 4", utils.InputName(r), len(storm))
+	}
+	return Forecast{storm, opts}, nil
 }
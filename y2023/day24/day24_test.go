@@ -0,0 +1,32 @@
+package day24
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 24,
+		golden.Case{Input: "day24_test.txt", Options: registry.Options{"area-min": "7", "area-max": "27"}, Part1: 2, Part2: 47},
+		golden.Case{Input: "day24_test.txt", Options: registry.Options{"area-min": "27", "area-max": "7"}, Err: "area-min 27 is above area-max 7"},
+		golden.Case{Input: "day24_test2.txt", Err: "3 hailstones are not enough"},
+		golden.Case{Input: "day24_test3.txt", Err: "the rock never hits hailstone 6"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 24)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 24)
+}
+
+func FuzzParseHail(f *testing.F) {
+	golden.FuzzLines(f, 2023, 24, parseHail)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 24)
+}
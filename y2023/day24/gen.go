@@ -0,0 +1,43 @@
+package day24
+
+import (
+	"advent/gen"
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+)
+
+func init() {
+	gen.Register(2023, 24, Generate)
+}
+
+// Generate throws a rock first and then places every hailstone where the
+// rock hits it at a random time, so part 2 has an answer. Hailstones differ
+// from the rock in speed along every axis, and positions stay around the
+// test area of part 1 while keeping the equations of part 2 within int64.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	var rock Hail
+	for _, axis := range []*int64{&rock.pos.x, &rock.pos.y, &rock.pos.z} {
+		*axis = int64(gen.Between(rng, 220e12, 380e12))
+	}
+	for _, axis := range []*int64{&rock.vel.x, &rock.vel.y, &rock.vel.z} {
+		*axis = int64(gen.Between(rng, -300, 300))
+	}
+	for range size.Pick(5, 50, 300) {
+		time := int64(gen.Between(rng, 1e11, 5e11))
+		var hail Hail
+		for _, axis := range []struct{ pos, vel, rockPos, rockVel *int64 }{
+			{&hail.pos.x, &hail.vel.x, &rock.pos.x, &rock.vel.x},
+			{&hail.pos.y, &hail.vel.y, &rock.pos.y, &rock.vel.y},
+			{&hail.pos.z, &hail.vel.z, &rock.pos.z, &rock.vel.z},
+		} {
+			var delta int64
+			for delta == 0 || *axis.rockVel+delta == 0 {
+				delta = int64(gen.Between(rng, -300, 300))
+			}
+			*axis.vel = *axis.rockVel + delta
+			*axis.pos = *axis.rockPos - time*delta
+		}
+		fmt.Fprintf(w, "%d, %d, %d @ %d, %d, %d\n", hail.pos.x, hail.pos.y, hail.pos.z, hail.vel.x, hail.vel.y, hail.vel.z)
+	}
+}
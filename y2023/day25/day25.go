@@ -0,0 +1,239 @@
+package day25
+
+import (
+	"advent/registry"
+	"advent/utils"
+	"advent/utils/graph"
+	"advent/utils/logger"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+type Node string
+
+type Link struct {
+	a, b    Node
+	enabled bool
+}
+
+type Graph struct {
+	nodesToLinkIdxs map[Node][]int
+	links           []Link
+}
+
+func NewGraph(nodeRows [][]Node) (graph Graph) {
+	rand.Shuffle(len(nodeRows), func(i, j int) {
+		nodeRows[i], nodeRows[j] = nodeRows[j], nodeRows[i]
+	})
+	graph.nodesToLinkIdxs = map[Node][]int{}
+	for _, nodes := range nodeRows {
+		a := nodes[0]
+		for _, b := range nodes[1:] {
+			linkIdx := len(graph.links)
+			graph.links = append(graph.links, Link{a, b, true})
+			graph.nodesToLinkIdxs[a] = append(graph.nodesToLinkIdxs[a], linkIdx)
+			graph.nodesToLinkIdxs[b] = append(graph.nodesToLinkIdxs[b], linkIdx)
+		}
+	}
+	return
+}
+
+func (graph Graph) neighbors(node Node) (neighbors []Node) {
+	for _, linkidx := range graph.nodesToLinkIdxs[node] {
+		link := graph.links[linkidx]
+		if !link.enabled {
+			continue
+		}
+		neighbor := link.a
+		if neighbor == node {
+			neighbor = link.b
+		}
+		neighbors = append(neighbors, neighbor)
+	}
+	return
+}
+
+func (graph Graph) visitRec(node Node, groupNum int, visited map[Node]int) {
+	if visited[node] != 0 {
+		return
+	}
+	visited[node] = groupNum
+	for _, next := range graph.neighbors(node) {
+		graph.visitRec(next, groupNum, visited)
+	}
+	return
+}
+
+func (graph Graph) sizeOfGroupsMultiplied() (count int, product int) {
+	visited := map[Node]int{}
+	for node := range graph.nodesToLinkIdxs {
+		if visited[node] == 0 {
+			count++
+			graph.visitRec(node, count, visited)
+		}
+	}
+	sizes := map[int]int{}
+	for node := range graph.nodesToLinkIdxs {
+		groupNum := visited[node]
+		sizes[groupNum]++
+	}
+	product = 1
+	for _, size := range sizes {
+		product *= size
+	}
+	return
+}
+
+func (graph Graph) sizeOfGroupsAfterRemovingLinksMultiplied(n, startIdx int) (maxCount int, maxProduct int) {
+	if n == 0 {
+		return graph.sizeOfGroupsMultiplied()
+	}
+	for i := startIdx; i <= len(graph.links)-n; i++ {
+		graph.links[i].enabled = false
+		count, product := graph.sizeOfGroupsAfterRemovingLinksMultiplied(n-1, i+1)
+		if count == 2 {
+			return count, product
+		}
+		graph.links[i].enabled = true
+	}
+	return
+}
+
+// https://www.geeksforgeeks.org/introduction-and-implementation-of-kargers-algorithm-for-minimum-cut/z
+func (graph Graph) findCutWithLinksNumber(ctx context.Context, n int) (int, error) {
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		linksNum, product, err := graph.randomCut()
+		if err != nil {
+			return 0, err
+		}
+		if linksNum == 0 {
+			return 0, errors.New("the wires already fall apart in two groups")
+		}
+		if linksNum == n {
+			logger.From(ctx).Debug("found cut", "iterations", i)
+			return product, nil
+		}
+	}
+}
+
+// randomCut is Karger's algorithm: it merges the ends of random links until
+// two groups of nodes are left, and counts the links between them. Merging
+// the links in a shuffled order gives every link the same chance as picking
+// one at random each time.
+func (graph Graph) randomCut() (links int, product int, err error) {
+	group := make(map[Node]Node, len(graph.nodesToLinkIdxs))
+	size := make(map[Node]int, len(graph.nodesToLinkIdxs))
+	for node := range graph.nodesToLinkIdxs {
+		group[node], size[node] = node, 1
+	}
+	find := func(node Node) Node {
+		for group[node] != node {
+			group[node] = group[group[node]]
+			node = group[node]
+		}
+		return node
+	}
+	groups := len(group)
+	for _, i := range rand.Perm(len(graph.links)) {
+		if groups <= 2 {
+			break
+		}
+		a, b := find(graph.links[i].a), find(graph.links[i].b)
+		if a == b {
+			continue
+		}
+		if size[a] < size[b] {
+			a, b = b, a
+		}
+		group[b] = a
+		size[a] += size[b]
+		groups--
+	}
+	if groups != 2 {
+		return 0, 0, fmt.Errorf("the wires connect %d groups of components, not 2 to cut apart", groups)
+	}
+	product = 1
+	for node, root := range group {
+		if node == root {
+			product *= size[node]
+		}
+	}
+	for _, link := range graph.links {
+		if find(link.a) != find(link.b) {
+			links++
+		}
+	}
+	return
+}
+
+func parseNodes(line string) (nodes []Node, err error) {
+	if !strings.Contains(line, ":") {
+		return nil, errors.New("missing colon")
+	}
+	fields := utils.Fields(line, ": ")
+	if len(fields) < 2 {
+		return nil, errors.New("expected a component and its connections")
+	}
+	nodes = make([]Node, len(fields))
+	for i, field := range fields {
+		nodes[i] = Node(field)
+	}
+	return
+}
+
+type Diagram [][]Node
+
+type Options struct {
+	Cut int `opt:"cut" default:"3" min:"1" usage:"number of wires to disconnect"`
+}
+
+type Apparatus struct {
+	diagram Diagram
+	opts    Options
+}
+
+func (apparatus Apparatus) Part1(ctx context.Context) (int, error) {
+	graph := NewGraph(apparatus.diagram)
+	return graph.findCutWithLinksNumber(ctx, apparatus.opts.Cut)
+}
+
+// Day 25 has no second puzzle
+func (apparatus Apparatus) Part2(ctx context.Context) (int, error) {
+	return 0, registry.ErrNoPuzzle
+}
+
+// ExportGraph is the wiring diagram, every wire listed once.
+func (apparatus Apparatus) ExportGraph() *graph.Graph {
+	g := graph.New("wiring", false)
+	for _, nodes := range apparatus.diagram {
+		a := nodes[0]
+		for _, b := range nodes[1:] {
+			g.AddEdge(string(a), string(b), nil)
+		}
+	}
+	g.Sort()
+	return g
+}
+
+func aggregate(diagram Diagram, nodes []Node) Diagram {
+	return append(diagram, nodes)
+}
+
+func init() {
+	registry.RegisterWithOptions(2023, 25, Parse)
+}
+
+func Parse(r io.Reader, opts Options) (registry.Solver, error) {
+	diagram, err := utils.ProcessReader(r, Diagram{}, parseNodes, aggregate)
+	if err != nil {
+		return nil, err
+	}
+	return Apparatus{diagram, opts}, nil
+}
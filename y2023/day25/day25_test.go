@@ -0,0 +1,32 @@
+package day25
+
+import (
+	"advent/golden"
+	"advent/registry"
+	"testing"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Test(t, 2023, 25,
+		golden.Case{Input: "day25_test.txt", Part1: 54},
+		golden.Case{Input: "day25_test.txt", Options: registry.Options{"cut": "3"}, Part1: 54},
+		golden.Case{Input: "day25_test.txt", Options: registry.Options{"cut": "0"}, Err: "option cut: 0 is less than 1"},
+		golden.Case{Input: "day25_test2.txt", Err: "the wires connect 3 groups of components"},
+	)
+}
+
+func TestGenerated(t *testing.T) {
+	golden.Generated(t, 2023, 25)
+}
+
+func FuzzParse(f *testing.F) {
+	golden.Fuzz(f, 2023, 25)
+}
+
+func FuzzParseNodes(f *testing.F) {
+	golden.FuzzLines(f, 2023, 25, parseNodes)
+}
+
+func BenchmarkGolden(b *testing.B) {
+	golden.Benchmark(b, 2023, 25)
+}
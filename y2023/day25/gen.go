@@ -0,0 +1,50 @@
+package day25
+
+import (
+	"advent/gen"
+	"bufio"
+	"math/rand/v2"
+	"strings"
+)
+
+func init() {
+	gen.Register(2023, 25, Generate)
+}
+
+// Generate writes two groups of components wired densely enough that only
+// the three wires between the groups cut the apparatus in two. Every wire
+// is listed once, on the line of one of its ends.
+func Generate(w *bufio.Writer, rng *rand.Rand, size gen.Size) {
+	group := size.Pick(8, 100, 750)
+	names := gen.Names(rng, 2*group, 3)
+	wired := make(map[[2]int]bool)
+	lines := make([][]string, len(names))
+	wire := func(a, b int) {
+		if wired[[2]int{a, b}] || wired[[2]int{b, a}] {
+			return
+		}
+		wired[[2]int{a, b}] = true
+		if rng.IntN(2) == 0 {
+			a, b = b, a
+		}
+		lines[a] = append(lines[a], names[b])
+	}
+	for first := 0; first < len(names); first += group {
+		for a := first; a < first+group; a++ {
+			for _, b := range rng.Perm(group - 1)[:4] {
+				if b >= a-first {
+					b++
+				}
+				wire(a, first+b)
+			}
+		}
+	}
+	for inside := len(wired); len(wired) < inside+3; {
+		wire(rng.IntN(group), group+rng.IntN(group))
+	}
+	for a, line := range lines {
+		if len(line) > 0 {
+			w.WriteString(names[a] + ": " + strings.Join(line, " ") + "\n")
+		}
+	}
+}